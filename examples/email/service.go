@@ -20,8 +20,10 @@ func main() {
 	q.RegisterHandler("send_welcome_email", func(
 		ctx context.Context,
 		payload map[string]any,
+		fb goqueue.Feedback,
 	) error {
 		emailAddr := payload["email"].(string)
+		fb.Info("sending welcome email to " + emailAddr)
 		return SendWelcome(emailAddr)
 	})
 
@@ -17,7 +17,7 @@ func NewEmailService(host string) *EmailService {
 	return &EmailService{smtpHost: host}
 }
 
-func (s *EmailService) SendWelcomeEmail(ctx context.Context, payload map[string]interface{}) error {
+func (s *EmailService) SendWelcomeEmail(ctx context.Context, payload map[string]interface{}, fb goqueue.Feedback) error {
 	email := payload["email"]
 	name := payload["name"]
 	log.Printf("📧 [EmailService] Sending welcome email to %v (%s)", name, email)
@@ -26,7 +26,7 @@ func (s *EmailService) SendWelcomeEmail(ctx context.Context, payload map[string]
 	return nil
 }
 
-func (s *EmailService) SendPasswordReset(ctx context.Context, payload map[string]interface{}) error {
+func (s *EmailService) SendPasswordReset(ctx context.Context, payload map[string]interface{}, fb goqueue.Feedback) error {
 	email := payload["email"]
 	log.Printf("🔒 [EmailService] Sending password reset to %v", email)
 	time.Sleep(200 * time.Millisecond)
@@ -42,7 +42,7 @@ func NewPaymentService(apiKey string) *PaymentService {
 	return &PaymentService{apiKey: apiKey}
 }
 
-func (s *PaymentService) ChargeCard(ctx context.Context, payload map[string]interface{}) error {
+func (s *PaymentService) ChargeCard(ctx context.Context, payload map[string]interface{}, fb goqueue.Feedback) error {
 	amount := payload["amount"]
 	customerID := payload["customer_id"]
 	log.Printf("💳 [PaymentService] Charging $%v to customer %v", amount, customerID)
@@ -58,7 +58,7 @@ func NewAnalyticsService() *AnalyticsService {
 	return &AnalyticsService{}
 }
 
-func (s *AnalyticsService) TrackEvent(ctx context.Context, payload map[string]interface{}) error {
+func (s *AnalyticsService) TrackEvent(ctx context.Context, payload map[string]interface{}, fb goqueue.Feedback) error {
 	event := payload["event"]
 	userID := payload["user_id"]
 	log.Printf("📊 [AnalyticsService] Tracking event '%v' for user %v", event, userID)
@@ -76,10 +76,7 @@ func main() {
 	analyticsService := NewAnalyticsService()
 
 	// Create queue
-	q := goqueue.New(
-		goqueue.WithWorkers(4),
-		goqueue.WithBufferSize(15),
-	)
+	q := goqueue.New()
 
 	// Register handlers - connecting job types to service methods
 	q.RegisterHandler("email.welcome", emailService.SendWelcomeEmail)
@@ -91,7 +88,7 @@ func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	go q.Start(ctx)
+	q.Start()
 	time.Sleep(100 * time.Millisecond)
 
 	log.Println("\n--- Submitting Jobs ---")
@@ -128,6 +125,6 @@ func main() {
 
 	log.Println("\n--- Shutting Down ---")
 	cancel()
-	time.Sleep(500 * time.Millisecond)
+	q.Stop()
 	log.Println("✅ All services stopped gracefully")
 }
@@ -23,43 +23,41 @@ func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	go q.Start(ctx)
-	time.Sleep(100 * time.Millisecond)
+	q.Start()
 
 	// Submit some jobs
-	jobID1, _ := q.Submit(ctx, "greet", map[string]interface{}{
+	job1, _ := q.Submit(ctx, "greet", map[string]interface{}{
 		"name": "Alice",
 	}, 3)
 
-	jobID2, _ := q.Submit(ctx, "calculate", map[string]interface{}{
+	job2, _ := q.Submit(ctx, "calculate", map[string]interface{}{
 		"operation": "add",
 		"a":         10,
 		"b":         5,
 	}, 3)
 
-	log.Printf("Submitted jobs: %s, %s", jobID1, jobID2)
+	log.Printf("Submitted jobs: %s, %s", job1.ID, job2.ID)
 
 	// Wait for processing
 	time.Sleep(2 * time.Second)
 
 	// Check job status
-	job, _ := q.GetJob(ctx, jobID1)
-	log.Printf("Job %s status: %s", job.ID, job.Status)
+	j, _ := q.GetJob(ctx, job1.ID)
+	log.Printf("Job %s status: %s", j.ID, j.Status)
 
 	// Shutdown
-	cancel()
-	time.Sleep(500 * time.Millisecond)
+	q.Stop()
 }
 
 // greetHandler is your custom business logic
-func greetHandler(ctx context.Context, payload map[string]interface{}) error {
+func greetHandler(ctx context.Context, payload map[string]interface{}, fb goqueue.Feedback) error {
 	name := payload["name"]
 	log.Printf("👋 Hello, %s!", name)
 	return nil
 }
 
 // calculateHandler demonstrates another handler
-func calculateHandler(ctx context.Context, payload map[string]interface{}) error {
+func calculateHandler(ctx context.Context, payload map[string]interface{}, fb goqueue.Feedback) error {
 	op := payload["operation"]
 	a := payload["a"].(int)
 	b := payload["b"].(int)
@@ -21,11 +21,8 @@ type App struct {
 func main() {
 	log.Println("=== GoQueue Web App Example ===")
 
-	// Create queue with custom configuration
-	q := goqueue.New(
-		goqueue.WithWorkers(5),
-		goqueue.WithBufferSize(20),
-	)
+	// Create queue with default configuration
+	q := goqueue.New()
 
 	// Register business logic handlers
 	q.RegisterHandler("send_notification", sendNotificationHandler)
@@ -35,10 +32,10 @@ func main() {
 	app := &App{queue: q}
 
 	// Start queue workers
-	ctx, cancel := context.WithCancel(context.Background())
+	_, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	go q.Start(ctx)
+	q.Start()
 
 	// Setup HTTP routes
 	mux := http.NewServeMux()
@@ -92,7 +89,7 @@ func (app *App) submitJobHandler(w http.ResponseWriter, r *http.Request) {
 		req.MaxRetry = 3
 	}
 
-	jobID, err := app.queue.Submit(r.Context(), req.Type, req.Payload, req.MaxRetry)
+	job, err := app.queue.Submit(r.Context(), req.Type, req.Payload, req.MaxRetry)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -100,7 +97,7 @@ func (app *App) submitJobHandler(w http.ResponseWriter, r *http.Request) {
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]string{
-		"job_id": jobID,
+		"job_id": job.ID,
 		"status": "queued",
 	})
 }
@@ -124,21 +121,21 @@ func (app *App) jobStatusHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 // Business logic handlers
-func sendNotificationHandler(ctx context.Context, payload map[string]interface{}) error {
+func sendNotificationHandler(ctx context.Context, payload map[string]interface{}, fb goqueue.Feedback) error {
 	user := payload["user"]
 	log.Printf("📧 Sending notification to user: %v", user)
 	time.Sleep(500 * time.Millisecond)
 	return nil
 }
 
-func processOrderHandler(ctx context.Context, payload map[string]interface{}) error {
+func processOrderHandler(ctx context.Context, payload map[string]interface{}, fb goqueue.Feedback) error {
 	orderID := payload["order_id"]
 	log.Printf("📦 Processing order: %v", orderID)
 	time.Sleep(1 * time.Second)
 	return nil
 }
 
-func generateInvoiceHandler(ctx context.Context, payload map[string]interface{}) error {
+func generateInvoiceHandler(ctx context.Context, payload map[string]interface{}, fb goqueue.Feedback) error {
 	customerID := payload["customer_id"]
 	log.Printf("🧾 Generating invoice for customer: %v", customerID)
 	time.Sleep(800 * time.Millisecond)
@@ -2,56 +2,257 @@ package goqueue
 
 import (
 	"context"
+	"time"
 
 	"github.com/MohamedAljoke/goqueue/internal/handler"
 	"github.com/MohamedAljoke/goqueue/internal/job"
+	"github.com/MohamedAljoke/goqueue/internal/scheduler"
+	"github.com/MohamedAljoke/goqueue/internal/storage"
 	"github.com/MohamedAljoke/goqueue/internal/worker"
 )
 
 type (
 	Job         = job.Job
 	Status      = job.Status
-	HandlerFunc = func(ctx context.Context, payload map[string]any) error
+	Feedback    = job.Feedback
+	HandlerFunc = func(ctx context.Context, payload map[string]any, fb Feedback) error
+	// Middleware wraps a HandlerFunc to add cross-cutting behavior around
+	// every dispatched job, or a single job type via RegisterHandlerWithMiddleware.
+	Middleware = handler.Middleware
 )
 
+// ErrUnchanged is returned by a handler to signal there was nothing to do;
+// the job is marked completed without counting as a failed attempt.
+var ErrUnchanged = job.ErrUnchanged
+
+// SubmitOpts carries the per-job knobs a caller can set at submit time. If
+// RunAt is in the future, the job is held as StatusScheduled and promoted
+// to pending by the scheduler once it's due instead of running right away.
+type SubmitOpts struct {
+	MaxRetry int
+	// Priority controls dispatch order: workers pull the highest-priority
+	// pending job first, breaking ties by submission time.
+	Priority int
+	RunAt    time.Time
+}
+
+// SubmitOption configures a single job submission. See WithPriority.
+type SubmitOption func(*SubmitOpts)
+
+// WithPriority sets the priority a job is submitted with; higher values are
+// dispatched before lower ones, so a high-priority job jumps ahead of
+// lower-priority jobs already waiting.
+func WithPriority(priority int) SubmitOption {
+	return func(o *SubmitOpts) { o.Priority = priority }
+}
+
 type Queue struct {
-	registry *handler.HandlerRegistry
-	pool     *worker.WorkerPool
+	registry  *handler.HandlerRegistry
+	storage   storage.Storage
+	pool      *worker.WorkerPool
+	scheduler *scheduler.Scheduler
 }
 
-func NewQueue() *Queue {
+// Option configures a Queue constructed with New. See WithStorage.
+type Option func(*queueConfig)
+
+type queueConfig struct {
+	storage storage.Storage
+	backoff job.BackoffStrategy
+}
+
+// WithStorage overrides the Storage backend a Queue persists jobs to, e.g.
+// WithStorage(postgresStore) so jobs survive restarts and can be shared
+// across processes instead of living only in memory.
+func WithStorage(s storage.Storage) Option {
+	return func(c *queueConfig) { c.storage = s }
+}
+
+// WithBackoff overrides the retry backoff strategy a Queue uses between a
+// failed attempt and the next one, e.g.
+// WithBackoff(job.ExponentialJitterBackoff{ExponentialBackoff: job.ExponentialBackoff{Base: time.Second, Max: time.Minute}}).
+// Defaults to job.DefaultBackoff. With a storage backend that hands out jobs
+// itself (such as Postgres), the delay is not applied: a failed job can be
+// claimed again as soon as its lease is released.
+func WithBackoff(b job.BackoffStrategy) Option {
+	return func(c *queueConfig) { c.backoff = b }
+}
+
+// New creates a Queue, defaulting to in-memory storage unless overridden
+// with WithStorage.
+func New(opts ...Option) *Queue {
+	cfg := queueConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.storage == nil {
+		cfg.storage = storage.NewMemory()
+	}
+
 	registry := handler.NewHandlerRegistry()
+	// Every handler gets panic recovery by default, so a caller doesn't have
+	// to remember Use(handler.Recover()) just to stop one bad handler from
+	// taking down a worker goroutine. Additional guarantees (timeout,
+	// metrics, tracing) remain opt-in via Use.
+	registry.Use(handler.Recover())
 	workerCount := 5
-	pool := worker.NewWorkerPool(workerCount, registry)
+	pool := worker.NewWorkerPool(workerCount, registry, cfg.storage, cfg.backoff)
 
-	return &Queue{
+	q := &Queue{
 		registry: registry,
+		storage:  cfg.storage,
 		pool:     pool,
 	}
+	q.scheduler = scheduler.NewScheduler(storage.NewMemorySchedules(), cfg.storage, pool, q, scheduler.InMemoryLeader{})
+
+	return q
+}
+
+// NewQueue creates a Queue backed by in-memory storage. It is equivalent to
+// New() with no options.
+func NewQueue() *Queue {
+	return New()
 }
 
 func (q *Queue) SubmitJob(ctx context.Context, jobType string, payload map[string]interface{}, maxRetry int) (*Job, error) {
+	return q.SubmitJobWithOpts(ctx, jobType, payload, SubmitOpts{MaxRetry: maxRetry})
+}
+
+// Submit is like SubmitJob but takes functional SubmitOptions, e.g.
+// Submit(ctx, "email", payload, maxRetry, goqueue.WithPriority(10)).
+func (q *Queue) Submit(ctx context.Context, jobType string, payload map[string]interface{}, maxRetry int, opts ...SubmitOption) (*Job, error) {
+	o := SubmitOpts{MaxRetry: maxRetry}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return q.SubmitJobWithOpts(ctx, jobType, payload, o)
+}
+
+// SubmitJobWithOpts is like SubmitJob but allows the caller to set the
+// job's priority (higher runs first) and other per-job knobs.
+func (q *Queue) SubmitJobWithOpts(ctx context.Context, jobType string, payload map[string]interface{}, opts SubmitOpts) (*Job, error) {
 	_, err := q.registry.Get(jobType)
 	if err != nil {
 		return nil, err
 	}
 
-	job := job.NewJob(maxRetry)
-	job.Type = jobType
-	job.Payload = payload
+	var j *Job
+	if opts.RunAt.After(time.Now()) {
+		j = job.NewScheduledJob(opts.MaxRetry, opts.RunAt)
+	} else {
+		j = job.NewJob(opts.MaxRetry)
+	}
+	j.Type = jobType
+	j.Payload = payload
+	j.Priority = opts.Priority
+
+	if err := q.storage.Save(ctx, j); err != nil {
+		return nil, err
+	}
+
+	if j.Status == job.StatusPending {
+		q.pool.Submit(j)
+	}
+
+	return j, nil
+}
+
+// SubmitAt submits a job that is held as StatusScheduled until runAt, when
+// the scheduler promotes it to pending and it runs like any other job.
+func (q *Queue) SubmitAt(ctx context.Context, runAt time.Time, jobType string, payload map[string]interface{}, maxRetry int) (*Job, error) {
+	return q.SubmitJobWithOpts(ctx, jobType, payload, SubmitOpts{MaxRetry: maxRetry, RunAt: runAt})
+}
+
+// SubmitIn submits a job that runs after delay has elapsed.
+func (q *Queue) SubmitIn(ctx context.Context, delay time.Duration, jobType string, payload map[string]interface{}, maxRetry int) (*Job, error) {
+	return q.SubmitAt(ctx, time.Now().Add(delay), jobType, payload, maxRetry)
+}
+
+// GetJob retrieves a job by ID, including any feedback logs and progress
+// recorded while it ran.
+func (q *Queue) GetJob(ctx context.Context, id string) (*Job, error) {
+	return q.storage.Get(ctx, id)
+}
+
+// JobLogs returns the job's retained log tail and how many earlier lines
+// were dropped to keep it bounded, as reported via Feedback while it ran.
+func (q *Queue) JobLogs(ctx context.Context, id string) (logs []string, overflow int, err error) {
+	j, err := q.storage.Get(ctx, id)
+	if err != nil {
+		return nil, 0, err
+	}
+	return j.Logs, j.LogOverflow, nil
+}
+
+// JobProgress returns the job's last reported progress percentage, as set
+// via Feedback.Progress while it ran.
+func (q *Queue) JobProgress(ctx context.Context, id string) (float64, error) {
+	j, err := q.storage.Get(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	return j.Progress, nil
+}
+
+// ListDeadJobs returns every job that exhausted its retries and landed in
+// StatusDead, for an operator to inspect and optionally RequeueDead.
+func (q *Queue) ListDeadJobs(ctx context.Context) ([]*Job, error) {
+	return q.storage.List(ctx, job.StatusDead)
+}
+
+// RequeueDead moves a dead job back to pending and hands it to the worker
+// pool for a fresh attempt, resetting its attempt count so it gets the
+// full MaxRetry budget again.
+func (q *Queue) RequeueDead(ctx context.Context, id string) (*Job, error) {
+	j, err := q.storage.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := j.ChangeStatus(job.StatusPending); err != nil {
+		return nil, err
+	}
+	j.Attempts = 0
+	j.Error = ""
+
+	if err := q.storage.Update(ctx, j); err != nil {
+		return nil, err
+	}
 
-	q.pool.Submit(job)
+	q.pool.Submit(j)
 
-	return job, nil
+	return j, nil
 }
 
 func (q *Queue) RegisterHandler(jobType string, handlerFunc HandlerFunc) {
 	q.registry.Register(jobType, handlerFunc)
 }
 
+// RegisterHandlerWithMiddleware is like RegisterHandler but additionally
+// wraps handlerFunc with mw, applied only to this job type and nested
+// inside any global middleware installed with Use.
+func (q *Queue) RegisterHandlerWithMiddleware(jobType string, handlerFunc HandlerFunc, mw ...Middleware) {
+	q.registry.RegisterWithMiddleware(jobType, handlerFunc, mw...)
+}
+
+// Use installs middleware that wraps every registered handler, in the
+// order given, e.g. Use(handler.Recover(), handler.WithTimeout(30*time.Second)).
+func (q *Queue) Use(mw ...Middleware) {
+	q.registry.Use(mw...)
+}
+
+// RegisterSchedule registers a recurring or one-shot job. spec is one of
+// "@every <duration>", "@at <RFC3339 time>", or a standard 5-field cron
+// expression.
+func (q *Queue) RegisterSchedule(name, spec, jobType string, payload map[string]interface{}) error {
+	return q.scheduler.Register(context.Background(), name, jobType, payload, 0, spec)
+}
+
 func (q *Queue) Start() {
 	q.pool.Start()
+	q.scheduler.Start()
 }
 func (q *Queue) Stop() {
+	q.scheduler.Stop()
 	q.pool.Stop()
 }
@@ -4,99 +4,108 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/MohamedAljoke/goqueue"
-	"github.com/MohamedAljoke/goqueue/internal/entity"
-	"github.com/MohamedAljoke/goqueue/internal/storage"
-	"github.com/MohamedAljoke/goqueue/internal/usecase"
+	"github.com/MohamedAljoke/goqueue/internal/job"
 )
 
-func TestJobProcessing(t *testing.T) {
+// waitForStatus polls GetJob until id reaches want, failing the test if it
+// doesn't within a couple seconds. Processing happens on a worker pool
+// goroutine, so tests can't just check the status synchronously after
+// SubmitJob returns.
+func waitForStatus(t *testing.T, q *goqueue.Queue, id string, want job.Status) *goqueue.Job {
+	t.Helper()
 
-	t.Run("should create job with pending status", func(t *testing.T) {
-		jobStorage := storage.NewMemoryStorage()
-		jobStorage.ClearStorage()
-
-		job := entity.NewJob(3)
-		jobStorage.SaveJob(context.Background(), job)
-
-		if job.Status != entity.StatusPending {
-			t.Errorf("expected job status to be 'pending', got %s", job.Status)
-		}
-		retrieved, exists := jobStorage.GetJob(context.Background(), job.ID)
-		if !exists {
-			t.Errorf("expected job to exist in storage")
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		j, err := q.GetJob(context.Background(), id)
+		if err != nil {
+			t.Fatalf("GetJob(%s): %v", id, err)
 		}
-		if retrieved.ID != job.ID {
-			t.Errorf("expected job ID %s, got %s", job.ID, retrieved.ID)
+		if j.Status == want {
+			return j
 		}
-	})
+		time.Sleep(5 * time.Millisecond)
+	}
 
-	t.Run("should have status completed after processing", func(t *testing.T) {
-		jobStorage := storage.NewMemoryStorage()
-		jobStorage.ClearStorage()
-		processor := usecase.NewJobProcessor(jobStorage)
+	t.Fatalf("job %s never reached status %s", id, want)
+	return nil
+}
 
-		job := entity.NewJob(3)
-		handler := func(ctx context.Context, payload map[string]interface{}) error {
+func TestQueue_SubmitJob(t *testing.T) {
+	t.Run("should process job with registered handler", func(t *testing.T) {
+		q := goqueue.NewQueue()
+		q.RegisterHandler("email", func(ctx context.Context, payload map[string]interface{}, fb goqueue.Feedback) error {
 			return nil
-		}
+		})
+		q.Start()
+		defer q.Stop()
 
-		err := processor.ProcessJob(context.Background(), job, handler)
+		j, err := q.SubmitJob(context.Background(), "email", map[string]interface{}{"to": "test@test.com"}, 3)
 		if err != nil {
-			t.Errorf("expected no error, got %v", err)
+			t.Fatalf("unexpected error: %v", err)
 		}
-		if job.Status != entity.StatusCompleted {
-			t.Errorf("expected job status to be 'completed', got %s", job.Status)
+
+		got := waitForStatus(t, q, j.ID, job.StatusCompleted)
+		if got.Error != "" {
+			t.Errorf("expected no error on a completed job, got %q", got.Error)
 		}
 	})
 
-	t.Run("should have status failed after processing with error", func(t *testing.T) {
-		jobStorage := storage.NewMemoryStorage()
-		jobStorage.ClearStorage()
-		processor := usecase.NewJobProcessor(jobStorage)
-
-		job := entity.NewJob(1)
-		handler := func(ctx context.Context, payload map[string]interface{}) error {
-			return fmt.Errorf("handler error")
-		}
+	t.Run("should return error for unregistered handler", func(t *testing.T) {
+		q := goqueue.NewQueue()
 
-		err := processor.ProcessJob(context.Background(), job, handler)
+		j, err := q.SubmitJob(context.Background(), "unknown", map[string]interface{}{}, 3)
 		if err == nil {
-			t.Errorf("expected error, got nil")
+			t.Error("expected error for unregistered handler")
 		}
-		if job.Status != entity.StatusFailed {
-			t.Errorf("expected job status to be 'failed', got %s", job.Status)
+		if j != nil {
+			t.Error("expected nil job")
 		}
 	})
 
-	t.Run("should process job with registered handler", func(t *testing.T) {
-		queue := goqueue.NewQueue()
-
-		queue.RegisterHandler("email", func(ctx context.Context, payload map[string]interface{}) error {
-			return nil
+	t.Run("should land in dead status once retries are exhausted", func(t *testing.T) {
+		q := goqueue.NewQueue()
+		q.RegisterHandler("email", func(ctx context.Context, payload map[string]interface{}, fb goqueue.Feedback) error {
+			return fmt.Errorf("handler error")
 		})
+		q.Start()
+		defer q.Stop()
 
-		job, err := queue.SubmitJob(context.Background(), "email", map[string]interface{}{"to": "test@test.com"}, 3)
-
+		j, err := q.SubmitJob(context.Background(), "email", map[string]interface{}{}, 0)
 		if err != nil {
-			t.Errorf("expected no error, got %v", err)
+			t.Fatalf("unexpected error: %v", err)
 		}
-		if job.Status != entity.StatusCompleted {
-			t.Errorf("expected completed status, got %s", job.Status)
+
+		got := waitForStatus(t, q, j.ID, job.StatusDead)
+		if got.Error == "" {
+			t.Errorf("expected a recorded error on a dead job")
 		}
 	})
+}
 
-	t.Run("should return error for unregistered handler", func(t *testing.T) {
-		queue := goqueue.NewQueue()
+func TestQueue_GetJob(t *testing.T) {
+	t.Run("should return the job that was submitted", func(t *testing.T) {
+		q := goqueue.NewQueue()
+		q.RegisterHandler("email", func(ctx context.Context, payload map[string]interface{}, fb goqueue.Feedback) error {
+			return nil
+		})
 
-		job, err := queue.SubmitJob(context.Background(), "unknown", map[string]interface{}{}, 3)
+		submitted, err := q.SubmitJob(context.Background(), "email", map[string]interface{}{"to": "test@test.com"}, 3)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
 
-		if err == nil {
-			t.Error("expected error for unregistered handler")
+		got, err := q.GetJob(context.Background(), submitted.ID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
 		}
-		if job != nil {
-			t.Error("expected nil job")
+		if got.ID != submitted.ID {
+			t.Errorf("expected job ID %s, got %s", submitted.ID, got.ID)
+		}
+		if got.Status != job.StatusPending {
+			t.Errorf("expected a freshly submitted job to be pending, got %s", got.Status)
 		}
 	})
 }
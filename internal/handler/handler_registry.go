@@ -5,33 +5,65 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+
+	"github.com/MohamedAljoke/goqueue/internal/job"
 )
 
-type HandlerFunc func(ctx context.Context, payload map[string]any) error
+type HandlerFunc func(ctx context.Context, payload map[string]any, fb job.Feedback) error
+
+// Middleware wraps a HandlerFunc to add cross-cutting behavior (panic
+// recovery, timeouts, metrics, tracing) without individual handlers having
+// to implement it themselves. Middleware passed to Use wraps every
+// handler in the registry; middleware passed to RegisterWithMiddleware
+// wraps only that job type.
+type Middleware func(HandlerFunc) HandlerFunc
+
+type registeredHandler struct {
+	fn HandlerFunc
+	mw []Middleware
+}
 
 type HandlerRegistry struct {
-	handlers map[string]HandlerFunc
-	mu       sync.RWMutex
+	handlers   map[string]registeredHandler
+	middleware []Middleware
+	mu         sync.RWMutex
 }
 
 func NewHandlerRegistry() *HandlerRegistry {
 	return &HandlerRegistry{
-		handlers: make(map[string]HandlerFunc),
+		handlers: make(map[string]registeredHandler),
 	}
 }
 
+// Use registers middleware that wraps every handler in the registry, in
+// the order given: the first middleware passed is outermost, so it sees a
+// job before later ones and sees the outcome after them; a panic that an
+// inner Recover middleware has converted reaches it as an ordinary error.
+func (r *HandlerRegistry) Use(mw ...Middleware) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.middleware = append(r.middleware, mw...)
+}
+
 func (r *HandlerRegistry) Register(jobType string, handler HandlerFunc) {
+	r.RegisterWithMiddleware(jobType, handler)
+}
+
+// RegisterWithMiddleware is like Register but additionally wraps handler
+// with mw, applied only to this job type and nested inside the registry's
+// global middleware from Use.
+func (r *HandlerRegistry) RegisterWithMiddleware(jobType string, handler HandlerFunc, mw ...Middleware) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	r.handlers[jobType] = handler
+	r.handlers[jobType] = registeredHandler{fn: handler, mw: mw}
 }
 
-var ErrRegistryNotFound = errors.New("handler registered for job type")
+var ErrRegistryNotFound = errors.New("no handler registered for job type")
 
 func (r *HandlerRegistry) Get(jobType string) (HandlerFunc, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
-	h, ok := r.handlers[jobType]
+	rh, ok := r.handlers[jobType]
 
 	if !ok {
 		return nil, fmt.Errorf(
@@ -41,5 +73,13 @@ func (r *HandlerRegistry) Get(jobType string) (HandlerFunc, error) {
 		)
 	}
 
+	h := rh.fn
+	for i := len(rh.mw) - 1; i >= 0; i-- {
+		h = rh.mw[i](h)
+	}
+	for i := len(r.middleware) - 1; i >= 0; i-- {
+		h = r.middleware[i](h)
+	}
+
 	return h, nil
 }
@@ -0,0 +1,73 @@
+package handler_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/MohamedAljoke/goqueue/internal/handler"
+	"github.com/MohamedAljoke/goqueue/internal/job"
+)
+
+func TestHandlerRegistry_Get(t *testing.T) {
+	t.Run("should return an error for an unregistered job type", func(t *testing.T) {
+		r := handler.NewHandlerRegistry()
+
+		_, err := r.Get("missing")
+		if !errors.Is(err, handler.ErrRegistryNotFound) {
+			t.Fatalf("expected ErrRegistryNotFound, got %v", err)
+		}
+	})
+
+	t.Run("should run global middleware outside per-handler middleware", func(t *testing.T) {
+		r := handler.NewHandlerRegistry()
+
+		var order []string
+		track := func(name string) handler.Middleware {
+			return func(next handler.HandlerFunc) handler.HandlerFunc {
+				return func(ctx context.Context, payload map[string]any, fb job.Feedback) error {
+					order = append(order, name)
+					return next(ctx, payload, fb)
+				}
+			}
+		}
+
+		r.Use(track("global"))
+		r.RegisterWithMiddleware("email", func(ctx context.Context, payload map[string]any, fb job.Feedback) error {
+			order = append(order, "handler")
+			return nil
+		}, track("local"))
+
+		h, err := r.Get("email")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := h(context.Background(), nil, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		want := []string{"global", "local", "handler"}
+		if len(order) != len(want) {
+			t.Fatalf("expected order %v, got %v", want, order)
+		}
+		for i := range want {
+			if order[i] != want[i] {
+				t.Fatalf("expected order %v, got %v", want, order)
+			}
+		}
+	})
+}
+
+func TestRecover(t *testing.T) {
+	t.Run("should convert a handler panic into an error", func(t *testing.T) {
+		h := handler.Recover()(func(ctx context.Context, payload map[string]any, fb job.Feedback) error {
+			panic("boom")
+		})
+
+		err := h(context.Background(), nil, nil)
+		if err == nil {
+			t.Fatalf("expected an error from the recovered panic")
+		}
+	})
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/MohamedAljoke/goqueue/internal/job"
+)
+
+var (
+	jobsProcessedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "jobs_processed_total",
+			Help: "Total number of jobs processed, labeled by job type and outcome status.",
+		},
+		[]string{"type", "status"},
+	)
+	jobDurationSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "job_duration_seconds",
+			Help: "Handler execution time in seconds, labeled by job type and outcome status.",
+		},
+		[]string{"type", "status"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(jobsProcessedTotal, jobDurationSeconds)
+}
+
+// Metrics records jobs_processed_total and job_duration_seconds for every
+// invocation of the handler it wraps, labeled with jobType and whether the
+// handler returned an error.
+func Metrics(jobType string) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, payload map[string]any, fb job.Feedback) error {
+			start := time.Now()
+			err := next(ctx, payload, fb)
+
+			status := "completed"
+			if err != nil {
+				status = "failed"
+			}
+			jobsProcessedTotal.WithLabelValues(jobType, status).Inc()
+			jobDurationSeconds.WithLabelValues(jobType, status).Observe(time.Since(start).Seconds())
+
+			return err
+		}
+	}
+}
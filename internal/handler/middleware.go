@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/MohamedAljoke/goqueue/internal/job"
+)
+
+// Recover converts a handler panic into a normal error, with the stack
+// trace attached, instead of letting it crash the worker goroutine.
+func Recover() Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, payload map[string]any, fb job.Feedback) (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					err = fmt.Errorf("handler panic: %v\n%s", r, debug.Stack())
+				}
+			}()
+
+			return next(ctx, payload, fb)
+		}
+	}
+}
+
+// WithTimeout bounds how long a handler may run before its context is
+// cancelled.
+func WithTimeout(d time.Duration) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, payload map[string]any, fb job.Feedback) error {
+			ctx, cancel := context.WithTimeout(ctx, d)
+			defer cancel()
+
+			return next(ctx, payload, fb)
+		}
+	}
+}
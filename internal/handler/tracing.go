@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/MohamedAljoke/goqueue/internal/job"
+)
+
+var tracer = otel.Tracer("github.com/MohamedAljoke/goqueue/internal/handler")
+
+// Tracing starts an OpenTelemetry span around each invocation of the
+// handler it wraps, tagged with jobType and recording the handler's error
+// (if any) on the span.
+func Tracing(jobType string) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, payload map[string]any, fb job.Feedback) error {
+			ctx, span := tracer.Start(ctx, "goqueue.job")
+			defer span.End()
+			span.SetAttributes(attribute.String("goqueue.job_type", jobType))
+
+			err := next(ctx, payload, fb)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+
+			return err
+		}
+	}
+}
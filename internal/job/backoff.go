@@ -0,0 +1,72 @@
+package job
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// BackoffStrategy computes how long a worker should wait before retrying a
+// job that just failed, given how many attempts it has made so far.
+type BackoffStrategy interface {
+	NextDelay(attempts int) time.Duration
+}
+
+// DefaultBackoff reproduces goqueue's original attempts^2-second backoff.
+// It's used when a Queue isn't configured with WithBackoff.
+type DefaultBackoff struct{}
+
+func (DefaultBackoff) NextDelay(attempts int) time.Duration {
+	return time.Duration(attempts*attempts) * time.Second
+}
+
+// LinearBackoff waits Step*attempts, capped at Max (if Max > 0).
+type LinearBackoff struct {
+	Step time.Duration
+	Max  time.Duration
+}
+
+func (b LinearBackoff) NextDelay(attempts int) time.Duration {
+	d := b.Step * time.Duration(attempts)
+	if b.Max > 0 && d > b.Max {
+		return b.Max
+	}
+	return d
+}
+
+// ExponentialBackoff waits Base*Multiplier^attempts, capped at Max (if
+// Max > 0). Multiplier defaults to 2 when zero.
+type ExponentialBackoff struct {
+	Base       time.Duration
+	Max        time.Duration
+	Multiplier float64
+}
+
+func (b ExponentialBackoff) NextDelay(attempts int) time.Duration {
+	multiplier := b.Multiplier
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+
+	d := time.Duration(float64(b.Base) * math.Pow(multiplier, float64(attempts)))
+	if b.Max > 0 && d > b.Max {
+		return b.Max
+	}
+	return d
+}
+
+// ExponentialJitterBackoff applies full jitter on top of an
+// ExponentialBackoff: a random duration in [0, cap), where cap is the
+// underlying exponential delay. This spreads out retries that would
+// otherwise all wake up at once after a correlated failure.
+type ExponentialJitterBackoff struct {
+	ExponentialBackoff
+}
+
+func (b ExponentialJitterBackoff) NextDelay(attempts int) time.Duration {
+	cap := b.ExponentialBackoff.NextDelay(attempts)
+	if cap <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(cap)))
+}
@@ -0,0 +1,84 @@
+package job_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MohamedAljoke/goqueue/internal/job"
+)
+
+func TestDefaultBackoff_NextDelay(t *testing.T) {
+	tests := []struct {
+		attempts int
+		expected time.Duration
+	}{
+		{attempts: 0, expected: 0},
+		{attempts: 1, expected: time.Second},
+		{attempts: 2, expected: 4 * time.Second},
+		{attempts: 3, expected: 9 * time.Second},
+	}
+
+	for _, tt := range tests {
+		d := job.DefaultBackoff{}.NextDelay(tt.attempts)
+		if d != tt.expected {
+			t.Errorf("attempts %d: expected %s, got %s", tt.attempts, tt.expected, d)
+		}
+	}
+}
+
+func TestLinearBackoff_NextDelay(t *testing.T) {
+	b := job.LinearBackoff{Step: time.Second, Max: 3 * time.Second}
+
+	tests := []struct {
+		attempts int
+		expected time.Duration
+	}{
+		{attempts: 1, expected: time.Second},
+		{attempts: 2, expected: 2 * time.Second},
+		{attempts: 5, expected: 3 * time.Second}, // capped
+	}
+
+	for _, tt := range tests {
+		d := b.NextDelay(tt.attempts)
+		if d != tt.expected {
+			t.Errorf("attempts %d: expected %s, got %s", tt.attempts, tt.expected, d)
+		}
+	}
+}
+
+func TestExponentialBackoff_NextDelay(t *testing.T) {
+	b := job.ExponentialBackoff{Base: time.Second, Multiplier: 2, Max: 10 * time.Second}
+
+	tests := []struct {
+		attempts int
+		expected time.Duration
+	}{
+		{attempts: 0, expected: time.Second},
+		{attempts: 1, expected: 2 * time.Second},
+		{attempts: 2, expected: 4 * time.Second},
+		{attempts: 10, expected: 10 * time.Second}, // capped
+	}
+
+	for _, tt := range tests {
+		d := b.NextDelay(tt.attempts)
+		if d != tt.expected {
+			t.Errorf("attempts %d: expected %s, got %s", tt.attempts, tt.expected, d)
+		}
+	}
+}
+
+func TestExponentialJitterBackoff_NextDelay(t *testing.T) {
+	b := job.ExponentialJitterBackoff{
+		ExponentialBackoff: job.ExponentialBackoff{Base: time.Second, Multiplier: 2, Max: 10 * time.Second},
+	}
+
+	for attempts := 0; attempts < 5; attempts++ {
+		cap := b.ExponentialBackoff.NextDelay(attempts)
+		for i := 0; i < 20; i++ {
+			d := b.NextDelay(attempts)
+			if d < 0 || d >= cap {
+				t.Fatalf("attempts %d: expected delay in [0, %s), got %s", attempts, cap, d)
+			}
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package job
+
+import (
+	"context"
+	"time"
+)
+
+// Lease represents a worker's exclusive claim on a job for up to a TTL. The
+// holder renews it with Heartbeat while its handler is still running, and
+// gives it up with Release once the job reaches a terminal (or requeued)
+// status.
+type Lease interface {
+	ExpiresAt() time.Time
+	Heartbeat(ctx context.Context) error
+	Release(ctx context.Context, status Status) error
+}
+
+// Dispatcher hands the next runnable job to a worker under a Lease, so a
+// worker that dies mid-job doesn't hold it forever: once the lease expires
+// unrenewed, another worker (possibly in another process) can acquire the
+// same job again. This is implemented in-process by the worker package's
+// memoryDispatcher and,
+// for multi-process deployments, by storage.Postgres.
+type Dispatcher interface {
+	Acquire(ctx context.Context, workerID string) (*Job, Lease, error)
+}
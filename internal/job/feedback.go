@@ -0,0 +1,63 @@
+package job
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Feedback lets a handler report structured diagnostics and progress for
+// the job it's processing, so long-running jobs stay observable without
+// the caller wiring up their own logger.
+type Feedback interface {
+	Info(msg string)
+	Warn(msg string)
+	Error(msg string)
+	Progress(pct float64)
+}
+
+// maxLogLines bounds how many log lines a Job retains; older lines are
+// dropped and counted in Job.LogOverflow instead of growing Logs forever.
+const maxLogLines = 100
+
+// jobFeedback buffers log lines and the last progress value directly onto
+// the Job it's bound to. It's safe for concurrent use since a handler may
+// report progress from a goroutine it spawns.
+type jobFeedback struct {
+	mu  sync.Mutex
+	job *Job
+}
+
+// NewFeedback returns a Feedback that records onto j.
+func NewFeedback(j *Job) Feedback {
+	return &jobFeedback{job: j}
+}
+
+func (f *jobFeedback) Info(msg string) {
+	f.append("INFO", msg)
+}
+
+func (f *jobFeedback) Warn(msg string) {
+	f.append("WARN", msg)
+}
+
+func (f *jobFeedback) Error(msg string) {
+	f.append("ERROR", msg)
+}
+
+func (f *jobFeedback) Progress(pct float64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.job.Progress = pct
+}
+
+func (f *jobFeedback) append(level, msg string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.job.Logs = append(f.job.Logs, fmt.Sprintf("[%s] %s", level, msg))
+	if over := len(f.job.Logs) - maxLogLines; over > 0 {
+		f.job.LogOverflow += over
+		f.job.Logs = f.job.Logs[over:]
+	}
+}
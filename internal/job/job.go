@@ -10,27 +10,47 @@ import (
 type (
 	Status string
 	Job    struct {
-		ID        string
-		Type      string
-		Payload   map[string]any
-		Status    Status
-		Attempts  int
-		MaxRetry  int
-		Error     string
-		CreatedAt time.Time
-		UpdatedAt time.Time
+		ID       string
+		Type     string
+		Payload  map[string]any
+		Status   Status
+		Attempts int
+		MaxRetry int
+		Priority int
+		Error    string
+		// Logs holds at most maxLogLines entries; LogOverflow counts how
+		// many earlier lines were dropped to keep it bounded.
+		Logs        []string
+		LogOverflow int
+		Progress    float64
+		RunAt       time.Time
+		ScheduleID  string
+		CreatedAt   time.Time
+		UpdatedAt   time.Time
 	}
 )
 
 const (
+	// StatusScheduled is held by a job submitted via SubmitAt/SubmitIn (or
+	// by a recurring schedule) until its RunAt time arrives, at which point
+	// the scheduler promotes it to StatusPending.
+	StatusScheduled  = Status("scheduled")
 	StatusPending    = Status("pending")
 	StatusProcessing = Status("processing")
 	StatusCompleted  = Status("completed")
-	StatusFailed     = Status("failed")
+	// StatusDead is where a job lands once MarkFailed sees CanRetry return
+	// false, so it can be listed and manually requeued (see RequeueDead)
+	// instead of retried automatically.
+	StatusDead = Status("dead")
 )
 
 var ErrInvalidStatusTransition = errors.New("invalid status transition")
 
+// ErrUnchanged is returned by a handler to signal that there was nothing to
+// do for this job. The processor marks the job completed without treating
+// it as a failure and without counting it against MaxRetry.
+var ErrUnchanged = errors.New("job unchanged")
+
 func NewJob(maxRetry int) *Job {
 	now := time.Now()
 	job := &Job{
@@ -46,6 +66,16 @@ func NewJob(maxRetry int) *Job {
 	return job
 }
 
+// NewScheduledJob creates a job held back until runAt, at which point the
+// scheduler promotes it to StatusPending so a worker can pick it up.
+func NewScheduledJob(maxRetry int, runAt time.Time) *Job {
+	job := NewJob(maxRetry)
+	job.Status = StatusScheduled
+	job.RunAt = runAt
+
+	return job
+}
+
 func (job *Job) ChangeStatus(status Status) error {
 	if !job.canTransition(job.Status, status) {
 		return fmt.Errorf(
@@ -65,11 +95,6 @@ func (j *Job) CanRetry() bool {
 	return j.Attempts < j.MaxRetry
 }
 
-func (j *Job) BackoffDuration() time.Duration {
-	backoff := j.Attempts * j.Attempts
-	return time.Duration(backoff) * time.Second
-}
-
 func (j *Job) MarkRunning() error {
 	if err := j.ChangeStatus(StatusProcessing); err != nil {
 		return err
@@ -96,7 +121,7 @@ func (j *Job) MarkFailed(err error) error {
 	if j.CanRetry() {
 		targetStatus = StatusPending
 	} else {
-		targetStatus = StatusFailed
+		targetStatus = StatusDead
 	}
 
 	return j.ChangeStatus(targetStatus)
@@ -104,15 +129,19 @@ func (j *Job) MarkFailed(err error) error {
 
 func (job *Job) canTransition(from, to Status) bool {
 	validTransitions := map[Status][]Status{
+		StatusScheduled: {
+			StatusPending,
+		},
 		StatusPending: {
 			StatusProcessing,
 		},
 		StatusProcessing: {
 			StatusCompleted,
-			StatusFailed,
 			StatusPending,
+			StatusDead,
 		},
-		StatusFailed:    {},
+		// RequeueDead moves a dead job back to pending for a manual retry.
+		StatusDead:      {StatusPending},
 		StatusCompleted: {},
 	}
 
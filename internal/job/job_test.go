@@ -2,9 +2,7 @@ package job_test
 
 import (
 	"errors"
-	"fmt"
 	"testing"
-	"time"
 
 	"github.com/MohamedAljoke/goqueue/internal/job"
 )
@@ -82,35 +80,6 @@ func TestJob_RetryLogic(t *testing.T) {
 	})
 }
 
-func TestJob_BackoffDuration(t *testing.T) {
-	tests := []struct {
-		attempts int
-		expected int
-	}{
-		{attempts: 0, expected: 0},
-		{attempts: 1, expected: 1},
-		{attempts: 2, expected: 4},
-		{attempts: 3, expected: 9},
-		{attempts: 4, expected: 16},
-	}
-
-	for _, tt := range tests {
-		t.Run(fmt.Sprintf("attempts_%d", tt.attempts), func(t *testing.T) {
-			j := job.NewJob(3)
-			j.Attempts = tt.attempts
-
-			d := j.BackoffDuration()
-			if d != time.Duration(tt.expected)*time.Second {
-				t.Fatalf(
-					"expected backoff %ds, got %s",
-					tt.expected,
-					d,
-				)
-			}
-		})
-	}
-}
-
 func TestJob_MarkRunning(t *testing.T) {
 	t.Run("should move job to processing and increment attempts", func(t *testing.T) {
 		j := job.NewJob(3)
@@ -174,7 +143,7 @@ func TestJob_MarkFailed_WithRetry(t *testing.T) {
 }
 
 func TestJob_MarkFailed_NoRetry(t *testing.T) {
-	t.Run("should move job to failed when retries are exhausted", func(t *testing.T) {
+	t.Run("should move job to dead when retries are exhausted", func(t *testing.T) {
 		j := job.NewJob(1)
 
 		_ = j.MarkRunning()
@@ -184,8 +153,8 @@ func TestJob_MarkFailed_NoRetry(t *testing.T) {
 			t.Fatalf("unexpected error: %v", err)
 		}
 
-		if j.Status != job.StatusFailed {
-			t.Fatalf("expected status failed, got %s", j.Status)
+		if j.Status != job.StatusDead {
+			t.Fatalf("expected status dead, got %s", j.Status)
 		}
 	})
 }
@@ -0,0 +1,97 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// parsedCron holds the allowed values for each of the standard 5 cron
+// fields: minute, hour, day-of-month, month, day-of-week.
+type parsedCron struct {
+	minute, hour, dom, month, dow map[int]struct{}
+}
+
+func parseCron(spec string) (*parsedCron, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron spec must have 5 fields, got %d: %q", len(fields), spec)
+	}
+
+	bounds := [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 6}}
+	parsed := make([]map[int]struct{}, 5)
+	for i, f := range fields {
+		set, err := parseCronField(f, bounds[i][0], bounds[i][1])
+		if err != nil {
+			return nil, fmt.Errorf("cron field %d (%q): %w", i, f, err)
+		}
+		parsed[i] = set
+	}
+
+	return &parsedCron{minute: parsed[0], hour: parsed[1], dom: parsed[2], month: parsed[3], dow: parsed[4]}, nil
+}
+
+func parseCronField(field string, min, max int) (map[int]struct{}, error) {
+	base, step := field, 1
+	if parts := strings.SplitN(field, "/", 2); len(parts) == 2 {
+		base = parts[0]
+		s, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return nil, err
+		}
+		step = s
+	}
+
+	var lo, hi int
+	switch {
+	case base == "*":
+		lo, hi = min, max
+	case strings.Contains(base, "-"):
+		parts := strings.SplitN(base, "-", 2)
+		var err error
+		if lo, err = strconv.Atoi(parts[0]); err != nil {
+			return nil, err
+		}
+		if hi, err = strconv.Atoi(parts[1]); err != nil {
+			return nil, err
+		}
+	default:
+		v, err := strconv.Atoi(base)
+		if err != nil {
+			return nil, err
+		}
+		lo, hi = v, v
+	}
+
+	set := make(map[int]struct{})
+	for v := lo; v <= hi; v += step {
+		set[v] = struct{}{}
+	}
+
+	return set, nil
+}
+
+// next returns the first minute-aligned time strictly after from that
+// matches the cron spec.
+func (c *parsedCron) next(from time.Time) time.Time {
+	t := from.Truncate(time.Minute).Add(time.Minute)
+
+	// Bound the search so a spec that (due to a parsing quirk) never
+	// matches can't loop forever.
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		_, okMinute := c.minute[t.Minute()]
+		_, okHour := c.hour[t.Hour()]
+		_, okDom := c.dom[t.Day()]
+		_, okMonth := c.month[int(t.Month())]
+		_, okDow := c.dow[int(t.Weekday())]
+
+		if okMinute && okHour && okDom && okMonth && okDow {
+			return t
+		}
+		t = t.Add(time.Minute)
+	}
+
+	return limit
+}
@@ -0,0 +1,80 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCron(t *testing.T) {
+	t.Run("should reject a spec without 5 fields", func(t *testing.T) {
+		if _, err := parseCron("* * *"); err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+
+	t.Run("should reject a malformed field", func(t *testing.T) {
+		if _, err := parseCron("* * * * nope"); err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+
+	t.Run("should expand a step field", func(t *testing.T) {
+		c, err := parseCron("*/15 * * * *")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, want := range []int{0, 15, 30, 45} {
+			if _, ok := c.minute[want]; !ok {
+				t.Errorf("expected minute %d to be set", want)
+			}
+		}
+		if _, ok := c.minute[1]; ok {
+			t.Errorf("expected minute 1 to be unset")
+		}
+	})
+
+	t.Run("should expand a range field", func(t *testing.T) {
+		c, err := parseCron("0 9 * * 1-5")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, want := range []int{1, 2, 3, 4, 5} {
+			if _, ok := c.dow[want]; !ok {
+				t.Errorf("expected weekday %d to be set", want)
+			}
+		}
+		if _, ok := c.dow[0]; ok {
+			t.Errorf("expected Sunday (0) to be unset")
+		}
+	})
+}
+
+func TestParsedCron_Next(t *testing.T) {
+	t.Run("should find the next matching minute on the same day", func(t *testing.T) {
+		c, err := parseCron("30 14 * * *")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		from := time.Date(2026, 3, 10, 8, 0, 0, 0, time.UTC)
+		got := c.next(from)
+		want := time.Date(2026, 3, 10, 14, 30, 0, 0, time.UTC)
+		if !got.Equal(want) {
+			t.Errorf("expected %s, got %s", want, got)
+		}
+	})
+
+	t.Run("should roll over to the next day once today's slot has passed", func(t *testing.T) {
+		c, err := parseCron("30 14 * * *")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		from := time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC)
+		got := c.next(from)
+		want := time.Date(2026, 3, 11, 14, 30, 0, 0, time.UTC)
+		if !got.Equal(want) {
+			t.Errorf("expected %s, got %s", want, got)
+		}
+	})
+}
@@ -0,0 +1,219 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/MohamedAljoke/goqueue/internal/job"
+	"github.com/MohamedAljoke/goqueue/internal/storage"
+)
+
+// Enqueuer is the subset of Queue the scheduler needs to fire a due job.
+type Enqueuer interface {
+	SubmitJob(ctx context.Context, jobType string, payload map[string]interface{}, maxRetry int) (*job.Job, error)
+}
+
+// JobPool accepts an already-constructed job for dispatch. The scheduler
+// uses this to promote a StatusScheduled job (one submitted via
+// SubmitAt/SubmitIn) straight into the worker pool once it's due, without
+// minting a new job record the way Enqueuer does.
+type JobPool interface {
+	Submit(j *job.Job)
+}
+
+// LeaderElector grants exclusive scheduler leadership so that in a
+// multi-process deployment only one Scheduler instance actually fires due
+// schedules at a time.
+type LeaderElector interface {
+	AcquireLeader(ctx context.Context) (bool, error)
+	ReleaseLeader(ctx context.Context) error
+}
+
+// InMemoryLeader trivially grants leadership, since a single in-process
+// Scheduler has no peers to contend with.
+type InMemoryLeader struct{}
+
+func (InMemoryLeader) AcquireLeader(ctx context.Context) (bool, error) { return true, nil }
+func (InMemoryLeader) ReleaseLeader(ctx context.Context) error         { return nil }
+
+// Scheduler periodically enqueues jobs according to registered schedules:
+// cron expressions, fixed intervals, and one-shot future RunAt times.
+type Scheduler struct {
+	store    storage.ScheduleStorage
+	jobs     storage.Storage
+	pool     JobPool
+	enqueuer Enqueuer
+	leader   LeaderElector
+	tick     time.Duration
+
+	mu        sync.Mutex
+	schedules map[string]*storage.Schedule
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewScheduler creates a Scheduler that fires schedules onto enqueuer,
+// persisting definitions via store, and promotes delayed jobs (submitted
+// via SubmitAt/SubmitIn) from jobs storage onto pool once they're due.
+// leader decides who may act on a given tick; pass InMemoryLeader{} for a
+// single-process deployment.
+func NewScheduler(store storage.ScheduleStorage, jobs storage.Storage, pool JobPool, enqueuer Enqueuer, leader LeaderElector) *Scheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Scheduler{
+		store:     store,
+		jobs:      jobs,
+		pool:      pool,
+		enqueuer:  enqueuer,
+		leader:    leader,
+		tick:      time.Second,
+		schedules: make(map[string]*storage.Schedule),
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+}
+
+// Register adds (or replaces) a named schedule and persists it. spec is
+// parsed by parseSpec: "@every <duration>", "@at <RFC3339 time>", or a
+// standard 5-field cron expression.
+func (s *Scheduler) Register(ctx context.Context, name, jobType string, payload map[string]interface{}, maxRetry int, spec string) error {
+	interval, cron, runAt, err := parseSpec(spec)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	sch := &storage.Schedule{
+		Name:     name,
+		JobType:  jobType,
+		Payload:  payload,
+		MaxRetry: maxRetry,
+		Interval: interval,
+		Cron:     cron,
+		RunAt:    runAt,
+	}
+	sch.NextRunAt = nextRun(interval, cron, runAt, now)
+
+	s.mu.Lock()
+	s.schedules[name] = sch
+	s.mu.Unlock()
+
+	return s.store.SaveSchedule(ctx, sch)
+}
+
+// Start runs the scheduler loop in the background.
+func (s *Scheduler) Start() {
+	s.wg.Add(1)
+	go s.loop()
+}
+
+// Stop halts the scheduler loop and waits for it to exit.
+func (s *Scheduler) Stop() {
+	s.cancel()
+	s.wg.Wait()
+}
+
+func (s *Scheduler) loop() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.tick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			ok, err := s.leader.AcquireLeader(s.ctx)
+			if err != nil {
+				log.Printf("[SCHEDULER] leader election failed: %v", err)
+				continue
+			}
+			if !ok {
+				continue
+			}
+			s.runDue()
+			s.promoteDue()
+		}
+	}
+}
+
+// promoteDue moves every StatusScheduled job whose RunAt has arrived to
+// StatusPending and hands it to the worker pool. It continuously re-scans
+// jobs storage, so this is also how SubmitAt/SubmitIn jobs actually run.
+func (s *Scheduler) promoteDue() {
+	if s.jobs == nil || s.pool == nil {
+		return
+	}
+
+	due, err := s.jobs.List(s.ctx, job.StatusScheduled)
+	if err != nil {
+		log.Printf("[SCHEDULER] failed to list scheduled jobs: %v", err)
+		return
+	}
+
+	now := time.Now()
+	for _, j := range due {
+		if j.RunAt.After(now) {
+			continue
+		}
+
+		if err := j.ChangeStatus(job.StatusPending); err != nil {
+			log.Printf("[SCHEDULER] failed to promote job %s: %v", j.ID, err)
+			continue
+		}
+
+		if err := s.jobs.Update(s.ctx, j); err != nil {
+			log.Printf("[SCHEDULER] failed to persist promoted job %s: %v", j.ID, err)
+			continue
+		}
+
+		s.pool.Submit(j)
+	}
+}
+
+func (s *Scheduler) runDue() {
+	now := time.Now()
+
+	s.mu.Lock()
+	due := make([]*storage.Schedule, 0)
+	for _, sch := range s.schedules {
+		if !sch.NextRunAt.After(now) {
+			due = append(due, sch)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, sch := range due {
+		s.fire(sch, now)
+	}
+}
+
+func (s *Scheduler) fire(sch *storage.Schedule, now time.Time) {
+	if _, err := s.enqueuer.SubmitJob(s.ctx, sch.JobType, sch.Payload, sch.MaxRetry); err != nil {
+		log.Printf("[SCHEDULER] failed to enqueue schedule %s: %v", sch.Name, err)
+		return
+	}
+
+	sch.LastRunAt = now
+
+	isOneShot := sch.Interval == 0 && sch.Cron == ""
+	if isOneShot {
+		s.mu.Lock()
+		delete(s.schedules, sch.Name)
+		s.mu.Unlock()
+	} else {
+		sch.NextRunAt = nextRun(sch.Interval, sch.Cron, sch.RunAt, now)
+		s.mu.Lock()
+		s.schedules[sch.Name] = sch
+		s.mu.Unlock()
+	}
+
+	if err := s.store.UpdateSchedule(s.ctx, sch); err != nil {
+		log.Printf("[SCHEDULER] failed to persist schedule %s: %v", sch.Name, err)
+	}
+}
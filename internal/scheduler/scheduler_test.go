@@ -0,0 +1,165 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/MohamedAljoke/goqueue/internal/job"
+	"github.com/MohamedAljoke/goqueue/internal/storage"
+)
+
+// fakeEnqueuer records every SubmitJob call instead of actually enqueuing.
+type fakeEnqueuer struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (f *fakeEnqueuer) SubmitJob(ctx context.Context, jobType string, payload map[string]interface{}, maxRetry int) (*job.Job, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, jobType)
+	return job.NewJob(maxRetry), nil
+}
+
+// fakePool records every job submitted to it instead of actually running it.
+type fakePool struct {
+	mu   sync.Mutex
+	jobs []*job.Job
+}
+
+func (f *fakePool) Submit(j *job.Job) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.jobs = append(f.jobs, j)
+}
+
+func TestScheduler_Register(t *testing.T) {
+	t.Run("should persist the schedule with its computed NextRunAt", func(t *testing.T) {
+		store := storage.NewMemorySchedules()
+		s := NewScheduler(store, nil, nil, &fakeEnqueuer{}, InMemoryLeader{})
+
+		if err := s.Register(context.Background(), "daily-report", "report", nil, 3, "@every 1h"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		sch, err := store.GetSchedule(context.Background(), "daily-report")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if sch.Interval != time.Hour {
+			t.Errorf("expected interval 1h, got %s", sch.Interval)
+		}
+		if sch.NextRunAt.IsZero() {
+			t.Errorf("expected NextRunAt to be set")
+		}
+	})
+
+	t.Run("should reject an invalid spec", func(t *testing.T) {
+		store := storage.NewMemorySchedules()
+		s := NewScheduler(store, nil, nil, &fakeEnqueuer{}, InMemoryLeader{})
+
+		if err := s.Register(context.Background(), "bad", "report", nil, 3, "nonsense"); err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+}
+
+func TestScheduler_RunDue(t *testing.T) {
+	t.Run("should fire a due schedule and reschedule it", func(t *testing.T) {
+		store := storage.NewMemorySchedules()
+		enqueuer := &fakeEnqueuer{}
+		s := NewScheduler(store, nil, nil, enqueuer, InMemoryLeader{})
+
+		if err := s.Register(context.Background(), "every-minute", "tick", nil, 3, "@every 1m"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// Force it due now instead of waiting out the interval.
+		s.mu.Lock()
+		s.schedules["every-minute"].NextRunAt = time.Now()
+		s.mu.Unlock()
+
+		s.runDue()
+
+		if len(enqueuer.calls) != 1 || enqueuer.calls[0] != "tick" {
+			t.Fatalf("expected exactly one fire of %q, got %v", "tick", enqueuer.calls)
+		}
+
+		sch, err := store.GetSchedule(context.Background(), "every-minute")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !sch.NextRunAt.After(time.Now()) {
+			t.Errorf("expected NextRunAt to be pushed into the future after firing")
+		}
+	})
+
+	t.Run("should drop a one-shot schedule after it fires", func(t *testing.T) {
+		store := storage.NewMemorySchedules()
+		enqueuer := &fakeEnqueuer{}
+		s := NewScheduler(store, nil, nil, enqueuer, InMemoryLeader{})
+
+		if err := s.Register(context.Background(), "once", "welcome", nil, 3, "@at "+time.Now().Add(time.Hour).Format(time.RFC3339)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		s.mu.Lock()
+		s.schedules["once"].NextRunAt = time.Now()
+		s.mu.Unlock()
+
+		s.runDue()
+
+		s.mu.Lock()
+		_, stillRegistered := s.schedules["once"]
+		s.mu.Unlock()
+		if stillRegistered {
+			t.Errorf("expected the one-shot schedule to be removed after firing")
+		}
+	})
+}
+
+func TestScheduler_PromoteDue(t *testing.T) {
+	t.Run("should promote a due scheduled job to the pool", func(t *testing.T) {
+		jobs := storage.NewMemory()
+		pool := &fakePool{}
+		s := NewScheduler(storage.NewMemorySchedules(), jobs, pool, &fakeEnqueuer{}, InMemoryLeader{})
+
+		j := job.NewScheduledJob(3, time.Now().Add(-time.Minute))
+		if err := jobs.Save(context.Background(), j); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		s.promoteDue()
+
+		if len(pool.jobs) != 1 || pool.jobs[0].ID != j.ID {
+			t.Fatalf("expected job %s to be submitted to the pool", j.ID)
+		}
+
+		got, err := jobs.Get(context.Background(), j.ID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.Status != job.StatusPending {
+			t.Errorf("expected the job to be promoted to pending, got %s", got.Status)
+		}
+	})
+
+	t.Run("should leave a not-yet-due scheduled job alone", func(t *testing.T) {
+		jobs := storage.NewMemory()
+		pool := &fakePool{}
+		s := NewScheduler(storage.NewMemorySchedules(), jobs, pool, &fakeEnqueuer{}, InMemoryLeader{})
+
+		j := job.NewScheduledJob(3, time.Now().Add(time.Hour))
+		if err := jobs.Save(context.Background(), j); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		s.promoteDue()
+
+		if len(pool.jobs) != 0 {
+			t.Errorf("expected no jobs submitted, got %d", len(pool.jobs))
+		}
+	})
+}
@@ -0,0 +1,56 @@
+package scheduler
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// parseSpec interprets a schedule spec string, which is one of:
+//   - "@every <duration>"  a fixed interval, e.g. "@every 5m"
+//   - "@at <RFC3339 time>" a one-shot run at a future time
+//   - a standard 5-field cron expression otherwise
+func parseSpec(spec string) (interval time.Duration, cron string, runAt time.Time, err error) {
+	switch {
+	case strings.HasPrefix(spec, "@every "):
+		d, perr := time.ParseDuration(strings.TrimPrefix(spec, "@every "))
+		if perr != nil {
+			return 0, "", time.Time{}, fmt.Errorf("invalid @every spec %q: %w", spec, perr)
+		}
+		return d, "", time.Time{}, nil
+
+	case strings.HasPrefix(spec, "@at "):
+		t, perr := time.Parse(time.RFC3339, strings.TrimPrefix(spec, "@at "))
+		if perr != nil {
+			return 0, "", time.Time{}, fmt.Errorf("invalid @at spec %q: %w", spec, perr)
+		}
+		return 0, "", t, nil
+
+	default:
+		if _, perr := parseCron(spec); perr != nil {
+			return 0, "", time.Time{}, fmt.Errorf("invalid cron spec %q: %w", spec, perr)
+		}
+		return 0, spec, time.Time{}, nil
+	}
+}
+
+// nextRun computes the next time a schedule should fire, always measuring
+// from `from` rather than the missed NextRunAt. This coalesces any
+// intervals skipped while the leader was down into a single run instead of
+// backfilling a burst.
+func nextRun(interval time.Duration, cron string, runAt time.Time, from time.Time) time.Time {
+	switch {
+	case cron != "":
+		c, err := parseCron(cron)
+		if err != nil {
+			// Keep a bad spec from firing every tick; it'll be visible via
+			// LastRunAt never advancing.
+			return from.Add(24 * time.Hour)
+		}
+		return c.next(from)
+	case interval > 0:
+		return from.Add(interval)
+	default:
+		return runAt
+	}
+}
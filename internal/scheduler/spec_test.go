@@ -0,0 +1,88 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSpec(t *testing.T) {
+	t.Run("should parse an @every spec", func(t *testing.T) {
+		interval, cron, runAt, err := parseSpec("@every 5m")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if interval != 5*time.Minute {
+			t.Errorf("expected interval 5m, got %s", interval)
+		}
+		if cron != "" || !runAt.IsZero() {
+			t.Errorf("expected cron and runAt to be unset, got %q %v", cron, runAt)
+		}
+	})
+
+	t.Run("should reject an invalid @every duration", func(t *testing.T) {
+		if _, _, _, err := parseSpec("@every soon"); err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+
+	t.Run("should parse an @at spec", func(t *testing.T) {
+		want := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+		_, _, runAt, err := parseSpec("@at " + want.Format(time.RFC3339))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !runAt.Equal(want) {
+			t.Errorf("expected runAt %s, got %s", want, runAt)
+		}
+	})
+
+	t.Run("should reject a malformed @at time", func(t *testing.T) {
+		if _, _, _, err := parseSpec("@at not-a-time"); err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+
+	t.Run("should accept a 5-field cron expression", func(t *testing.T) {
+		_, cron, _, err := parseSpec("0 9 * * 1-5")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cron != "0 9 * * 1-5" {
+			t.Errorf("expected cron to be passed through, got %q", cron)
+		}
+	})
+
+	t.Run("should reject an invalid cron expression", func(t *testing.T) {
+		if _, _, _, err := parseSpec("not a cron spec"); err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+}
+
+func TestNextRun(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("should add the interval for @every schedules", func(t *testing.T) {
+		got := nextRun(5*time.Minute, "", time.Time{}, from)
+		want := from.Add(5 * time.Minute)
+		if !got.Equal(want) {
+			t.Errorf("expected %s, got %s", want, got)
+		}
+	})
+
+	t.Run("should return runAt as-is for one-shot schedules", func(t *testing.T) {
+		runAt := from.Add(24 * time.Hour)
+		got := nextRun(0, "", runAt, from)
+		if !got.Equal(runAt) {
+			t.Errorf("expected %s, got %s", runAt, got)
+		}
+	})
+
+	t.Run("should defer to the cron spec when set", func(t *testing.T) {
+		got := nextRun(0, "0 * * * *", time.Time{}, from)
+		want := from.Add(time.Hour)
+		if !got.Equal(want) {
+			t.Errorf("expected %s, got %s", want, got)
+		}
+	})
+}
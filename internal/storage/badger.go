@@ -0,0 +1,213 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/MohamedAljoke/goqueue/internal/job"
+)
+
+const (
+	jobKeyPrefix = "job:"
+	idxPrefix    = "idx/status/"
+)
+
+// defaultRecoveryThreshold bounds how long a job may sit in "processing"
+// before the startup recovery pass considers its worker dead and resets it
+// to pending.
+const defaultRecoveryThreshold = 5 * time.Minute
+
+// BadgerStorage is a durable Storage implementation built on top of the KV
+// abstraction, so it works with any embedded KV engine (BadgerDB by
+// default) rather than depending on one directly. Besides the job record
+// itself, it maintains a secondary index keyed by status so List doesn't
+// have to walk every job to find the ones it wants.
+type BadgerStorage struct {
+	kv                KV
+	recoveryThreshold time.Duration
+}
+
+// BadgerOption configures a BadgerStorage.
+type BadgerOption func(*BadgerStorage)
+
+// WithBadgerRecoveryThreshold sets how long a job may sit in "processing"
+// before the startup recovery pass considers its worker dead and resets it
+// to pending.
+func WithBadgerRecoveryThreshold(d time.Duration) BadgerOption {
+	return func(b *BadgerStorage) { b.recoveryThreshold = d }
+}
+
+// NewBadgerStorage wraps kv as a Storage. Use NewBadgerKV to get a KV
+// backed by an embedded BadgerDB database.
+//
+// On construction it runs a recovery pass: jobs already pending stay
+// discoverable via List as always, and any job stuck in "processing" since
+// before a prior process exited is reset to pending, so a crash doesn't
+// strand it there forever.
+func NewBadgerStorage(kv KV, opts ...BadgerOption) *BadgerStorage {
+	b := &BadgerStorage{kv: kv, recoveryThreshold: defaultRecoveryThreshold}
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	if n, err := b.recover(context.Background()); err != nil {
+		log.Printf("[BADGER] startup recovery: %v", err)
+	} else if n > 0 {
+		log.Printf("[BADGER] startup recovery: reset %d stuck job(s) to pending", n)
+	}
+
+	return b
+}
+
+func jobKey(id string) string {
+	return jobKeyPrefix + id
+}
+
+// idxKey locates a job under its status and creation time, so List can
+// prefix-scan a single status instead of filtering every job in Go.
+// createdAt is encoded so lexical and chronological order agree.
+func idxKey(status job.Status, createdAt time.Time, id string) string {
+	return fmt.Sprintf("%s%s/%s/%s", idxPrefix, status, createdAt.UTC().Format("2006-01-02T15:04:05.000000000Z07:00"), id)
+}
+
+func idxPrefixForStatus(status job.Status) string {
+	return fmt.Sprintf("%s%s/", idxPrefix, status)
+}
+
+func (b *BadgerStorage) Save(ctx context.Context, j *job.Job) error {
+	data, err := json.Marshal(j)
+	if err != nil {
+		return fmt.Errorf("badger storage: marshal job %s: %w", j.ID, err)
+	}
+
+	ops := []BatchOp{
+		{Key: jobKey(j.ID), Value: data},
+		{Key: idxKey(j.Status, j.CreatedAt, j.ID), Value: []byte(j.ID)},
+	}
+	if err := b.kv.Batch(ctx, ops); err != nil {
+		return fmt.Errorf("badger storage: save job %s: %w", j.ID, err)
+	}
+
+	return nil
+}
+
+func (b *BadgerStorage) Get(ctx context.Context, id string) (*job.Job, error) {
+	data, err := b.kv.Get(ctx, jobKey(id))
+	if errors.Is(err, ErrKVNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("badger storage: get job %s: %w", id, err)
+	}
+
+	var j job.Job
+	if err := json.Unmarshal(data, &j); err != nil {
+		return nil, fmt.Errorf("badger storage: unmarshal job %s: %w", id, err)
+	}
+
+	return &j, nil
+}
+
+// List returns every job with the given status via a prefix scan over the
+// status index, rather than walking every stored job.
+func (b *BadgerStorage) List(ctx context.Context, status job.Status) ([]*job.Job, error) {
+	var ids []string
+
+	err := b.kv.Iterate(ctx, idxPrefixForStatus(status), func(key string, value []byte) bool {
+		ids = append(ids, string(value))
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("badger storage: list jobs: %w", err)
+	}
+
+	result := make([]*job.Job, 0, len(ids))
+	for _, id := range ids {
+		j, err := b.Get(ctx, id)
+		if errors.Is(err, ErrNotFound) {
+			// Index entry outlived its job record; ignore it.
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, j)
+	}
+
+	return result, nil
+}
+
+func (b *BadgerStorage) Update(ctx context.Context, j *job.Job) error {
+	old, err := b.Get(ctx, j.ID)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(j)
+	if err != nil {
+		return fmt.Errorf("badger storage: marshal job %s: %w", j.ID, err)
+	}
+
+	ops := []BatchOp{{Key: jobKey(j.ID), Value: data}}
+	if old.Status != j.Status {
+		ops = append(ops,
+			BatchOp{Key: idxKey(old.Status, old.CreatedAt, old.ID), Value: nil},
+			BatchOp{Key: idxKey(j.Status, j.CreatedAt, j.ID), Value: []byte(j.ID)},
+		)
+	}
+
+	if err := b.kv.Batch(ctx, ops); err != nil {
+		return fmt.Errorf("badger storage: update job %s: %w", j.ID, err)
+	}
+
+	return nil
+}
+
+func (b *BadgerStorage) Delete(ctx context.Context, id string) error {
+	j, err := b.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	ops := []BatchOp{
+		{Key: jobKey(id), Value: nil},
+		{Key: idxKey(j.Status, j.CreatedAt, j.ID), Value: nil},
+	}
+	if err := b.kv.Batch(ctx, ops); err != nil {
+		return fmt.Errorf("badger storage: delete job %s: %w", id, err)
+	}
+
+	return nil
+}
+
+// recover resets jobs that have been "processing" for longer than
+// recoveryThreshold back to pending, in case the process that owned them
+// exited without marking them failed or completed. Jobs already pending
+// need no action: they stay discoverable via List(ctx, job.StatusPending)
+// exactly as they were before the restart.
+func (b *BadgerStorage) recover(ctx context.Context) (int64, error) {
+	jobs, err := b.List(ctx, job.StatusProcessing)
+	if err != nil {
+		return 0, fmt.Errorf("badger storage: recover stuck jobs: list: %w", err)
+	}
+
+	var reset int64
+	for _, j := range jobs {
+		if time.Since(j.UpdatedAt) < b.recoveryThreshold {
+			continue
+		}
+
+		j.Status = job.StatusPending
+		j.UpdatedAt = time.Now()
+		if err := b.Update(ctx, j); err != nil {
+			return reset, fmt.Errorf("badger storage: recover stuck jobs: reset %s: %w", j.ID, err)
+		}
+		reset++
+	}
+
+	return reset, nil
+}
@@ -0,0 +1,128 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	badger "github.com/dgraph-io/badger/v4"
+)
+
+// BadgerKV adapts an embedded BadgerDB instance to the KV interface.
+type BadgerKV struct {
+	db *badger.DB
+}
+
+// NewBadgerKV opens (creating if necessary) a BadgerDB database at dir.
+func NewBadgerKV(dir string) (*BadgerKV, error) {
+	db, err := badger.Open(badger.DefaultOptions(dir))
+	if err != nil {
+		return nil, fmt.Errorf("badger kv: open %s: %w", dir, err)
+	}
+
+	return &BadgerKV{db: db}, nil
+}
+
+// Close releases the underlying BadgerDB handle.
+func (b *BadgerKV) Close() error {
+	return b.db.Close()
+}
+
+func (b *BadgerKV) Get(ctx context.Context, key string) ([]byte, error) {
+	var value []byte
+
+	err := b.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get([]byte(key))
+		if err != nil {
+			return err
+		}
+		return item.Value(func(v []byte) error {
+			value = append([]byte(nil), v...)
+			return nil
+		})
+	})
+	if err == badger.ErrKeyNotFound {
+		return nil, ErrKVNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("badger kv: get %s: %w", key, err)
+	}
+
+	return value, nil
+}
+
+func (b *BadgerKV) Set(ctx context.Context, key string, value []byte) error {
+	err := b.db.Update(func(txn *badger.Txn) error {
+		return txn.Set([]byte(key), value)
+	})
+	if err != nil {
+		return fmt.Errorf("badger kv: set %s: %w", key, err)
+	}
+
+	return nil
+}
+
+func (b *BadgerKV) Delete(ctx context.Context, key string) error {
+	err := b.db.Update(func(txn *badger.Txn) error {
+		return txn.Delete([]byte(key))
+	})
+	if err != nil {
+		return fmt.Errorf("badger kv: delete %s: %w", key, err)
+	}
+
+	return nil
+}
+
+func (b *BadgerKV) Batch(ctx context.Context, ops []BatchOp) error {
+	err := b.db.Update(func(txn *badger.Txn) error {
+		for _, op := range ops {
+			if op.Value == nil {
+				if err := txn.Delete([]byte(op.Key)); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := txn.Set([]byte(op.Key), op.Value); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("badger kv: batch: %w", err)
+	}
+
+	return nil
+}
+
+func (b *BadgerKV) Iterate(ctx context.Context, prefix string, fn func(key string, value []byte) bool) error {
+	err := b.db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = []byte(prefix)
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		for it.Seek([]byte(prefix)); it.ValidForPrefix([]byte(prefix)); it.Next() {
+			item := it.Item()
+			key := string(item.KeyCopy(nil))
+
+			var cont bool
+			err := item.Value(func(v []byte) error {
+				cont = fn(key, append([]byte(nil), v...))
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			if !cont {
+				break
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("badger kv: iterate %s: %w", prefix, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,165 @@
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/MohamedAljoke/goqueue/internal/job"
+	"github.com/MohamedAljoke/goqueue/internal/storage"
+)
+
+func newTestBadgerStorage(t *testing.T, opts ...storage.BadgerOption) *storage.BadgerStorage {
+	t.Helper()
+
+	kv, err := storage.NewBadgerKV(t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error opening badger: %v", err)
+	}
+	t.Cleanup(func() { kv.Close() })
+
+	return storage.NewBadgerStorage(kv, opts...)
+}
+
+func TestBadgerStorage_SaveAndGet(t *testing.T) {
+	t.Run("should round-trip a saved job", func(t *testing.T) {
+		b := newTestBadgerStorage(t)
+		j := job.NewJob(3)
+		j.Type = "email"
+		j.Payload = map[string]any{"to": "a@b.com"}
+
+		if err := b.Save(context.Background(), j); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		got, err := b.Get(context.Background(), j.ID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.ID != j.ID || got.Type != j.Type {
+			t.Errorf("expected job %+v, got %+v", j, got)
+		}
+	})
+
+	t.Run("should return ErrNotFound for a missing job", func(t *testing.T) {
+		b := newTestBadgerStorage(t)
+
+		if _, err := b.Get(context.Background(), "missing"); !errors.Is(err, storage.ErrNotFound) {
+			t.Fatalf("expected ErrNotFound, got %v", err)
+		}
+	})
+}
+
+func TestBadgerStorage_List(t *testing.T) {
+	t.Run("should return only jobs with the requested status", func(t *testing.T) {
+		b := newTestBadgerStorage(t)
+
+		pending := job.NewJob(3)
+		if err := b.Save(context.Background(), pending); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		completed := job.NewJob(3)
+		completed.Status = job.StatusCompleted
+		if err := b.Save(context.Background(), completed); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		got, err := b.List(context.Background(), job.StatusPending)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(got) != 1 || got[0].ID != pending.ID {
+			t.Fatalf("expected only the pending job, got %v", got)
+		}
+	})
+}
+
+func TestBadgerStorage_Update(t *testing.T) {
+	t.Run("should move the job between status indexes", func(t *testing.T) {
+		b := newTestBadgerStorage(t)
+
+		j := job.NewJob(3)
+		if err := b.Save(context.Background(), j); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		j.Status = job.StatusCompleted
+		if err := b.Update(context.Background(), j); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		pending, err := b.List(context.Background(), job.StatusPending)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(pending) != 0 {
+			t.Errorf("expected no jobs left pending, got %d", len(pending))
+		}
+
+		completed, err := b.List(context.Background(), job.StatusCompleted)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(completed) != 1 || completed[0].ID != j.ID {
+			t.Fatalf("expected the job under completed, got %v", completed)
+		}
+	})
+}
+
+func TestBadgerStorage_Delete(t *testing.T) {
+	t.Run("should remove the job and its index entry", func(t *testing.T) {
+		b := newTestBadgerStorage(t)
+
+		j := job.NewJob(3)
+		if err := b.Save(context.Background(), j); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := b.Delete(context.Background(), j.ID); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if _, err := b.Get(context.Background(), j.ID); !errors.Is(err, storage.ErrNotFound) {
+			t.Errorf("expected ErrNotFound after delete, got %v", err)
+		}
+
+		pending, err := b.List(context.Background(), job.StatusPending)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(pending) != 0 {
+			t.Errorf("expected no dangling index entry, got %d", len(pending))
+		}
+	})
+}
+
+func TestNewBadgerStorage_RecoversStuckJobs(t *testing.T) {
+	t.Run("should reset an old processing job to pending on construction", func(t *testing.T) {
+		kv, err := storage.NewBadgerKV(t.TempDir())
+		if err != nil {
+			t.Fatalf("unexpected error opening badger: %v", err)
+		}
+		defer kv.Close()
+
+		b := storage.NewBadgerStorage(kv, storage.WithBadgerRecoveryThreshold(time.Millisecond))
+
+		j := job.NewJob(3)
+		j.Status = job.StatusProcessing
+		j.UpdatedAt = time.Now().Add(-time.Hour)
+		if err := b.Save(context.Background(), j); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		recovered := storage.NewBadgerStorage(kv, storage.WithBadgerRecoveryThreshold(time.Millisecond))
+
+		got, err := recovered.Get(context.Background(), j.ID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.Status != job.StatusPending {
+			t.Errorf("expected the stuck job to be reset to pending, got %s", got.Status)
+		}
+	})
+}
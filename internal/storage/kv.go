@@ -0,0 +1,33 @@
+package storage
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrKVNotFound is returned by a KV implementation when a key doesn't
+// exist.
+var ErrKVNotFound = errors.New("key not found")
+
+// KV is a minimal key-value abstraction that a durable Storage
+// implementation can be built on top of, independent of the underlying
+// embedded engine (BadgerDB today, BoltDB or similar tomorrow).
+type KV interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte) error
+	Delete(ctx context.Context, key string) error
+	// Iterate calls fn for every stored key with the given prefix, stopping
+	// early if fn returns false.
+	Iterate(ctx context.Context, prefix string, fn func(key string, value []byte) bool) error
+	// Batch applies ops atomically: either all of them are visible to a
+	// subsequent Get/Iterate, or none are. Callers rely on this to keep a
+	// job record and its secondary index entries from ever diverging.
+	Batch(ctx context.Context, ops []BatchOp) error
+}
+
+// BatchOp is a single write applied as part of a KV.Batch call. A BatchOp
+// with a nil Value deletes Key instead of setting it.
+type BatchOp struct {
+	Key   string
+	Value []byte
+}
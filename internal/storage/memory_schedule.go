@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"context"
+	"sync"
+)
+
+// MemorySchedules is an in-memory ScheduleStorage.
+type MemorySchedules struct {
+	mu        sync.RWMutex
+	schedules map[string]*Schedule
+}
+
+// NewMemorySchedules creates a new in-memory schedule store.
+func NewMemorySchedules() *MemorySchedules {
+	return &MemorySchedules{
+		schedules: make(map[string]*Schedule),
+	}
+}
+
+func (m *MemorySchedules) SaveSchedule(ctx context.Context, s *Schedule) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.schedules[s.Name] = s
+	return nil
+}
+
+func (m *MemorySchedules) GetSchedule(ctx context.Context, name string) (*Schedule, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	s, ok := m.schedules[name]
+	if !ok {
+		return nil, ErrScheduleNotFound
+	}
+
+	return s, nil
+}
+
+func (m *MemorySchedules) ListSchedules(ctx context.Context) ([]*Schedule, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make([]*Schedule, 0, len(m.schedules))
+	for _, s := range m.schedules {
+		result = append(result, s)
+	}
+
+	return result, nil
+}
+
+func (m *MemorySchedules) UpdateSchedule(ctx context.Context, s *Schedule) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.schedules[s.Name]; !ok {
+		return ErrScheduleNotFound
+	}
+
+	m.schedules[s.Name] = s
+	return nil
+}
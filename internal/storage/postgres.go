@@ -0,0 +1,523 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/MohamedAljoke/goqueue/internal/job"
+)
+
+// schedulerLeaderLockID is the advisory lock key used to elect a single
+// Scheduler leader across Postgres-backed Queue processes.
+const schedulerLeaderLockID = 727384
+
+const notifyChannel = "goqueue_new_job"
+
+const schema = `
+CREATE TABLE IF NOT EXISTS goqueue_jobs (
+	id          TEXT PRIMARY KEY,
+	type        TEXT NOT NULL,
+	payload     JSONB NOT NULL DEFAULT '{}',
+	status      TEXT NOT NULL,
+	attempts    INT NOT NULL DEFAULT 0,
+	max_retry   INT NOT NULL DEFAULT 0,
+	priority    INT NOT NULL DEFAULT 0,
+	error       TEXT NOT NULL DEFAULT '',
+	run_at      TIMESTAMPTZ,
+	leased_until TIMESTAMPTZ,
+	leased_by    TEXT NOT NULL DEFAULT '',
+	created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+	updated_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+`
+
+// Postgres is a durable Storage implementation backed by a goqueue_jobs
+// table. It lets multiple Queue processes share one pending job list:
+// workers claim the next row through Acquire, which uses SELECT ... FOR
+// UPDATE SKIP LOCKED and retries every pollInterval while none is runnable.
+// LISTEN/NOTIFY wakeups are available through Listen but do not wake
+// Acquire. Job logs, log overflow and progress are not stored, so Get and
+// List return them empty.
+type Postgres struct {
+	db              *sql.DB
+	leaseTimeout    time.Duration
+	pollInterval    time.Duration
+	janitorInterval time.Duration
+
+	leaderMu   sync.Mutex
+	leaderConn *sql.Conn
+
+	janitorCancel context.CancelFunc
+	janitorDone   chan struct{}
+}
+
+// PostgresOption configures a Postgres store.
+type PostgresOption func(*Postgres)
+
+// WithLeaseTimeout sets how long a job may sit in "processing" before the
+// recovery routine considers its worker dead and resets it to pending.
+func WithLeaseTimeout(d time.Duration) PostgresOption {
+	return func(p *Postgres) { p.leaseTimeout = d }
+}
+
+// WithPollInterval sets the fallback poll cadence used in case a NOTIFY is
+// missed (e.g. a worker reconnecting after a dropped connection).
+func WithPollInterval(d time.Duration) PostgresOption {
+	return func(p *Postgres) { p.pollInterval = d }
+}
+
+// WithJanitorInterval sets how often the background janitor checks for
+// jobs whose lease has expired. Defaults to 30 seconds.
+func WithJanitorInterval(d time.Duration) PostgresOption {
+	return func(p *Postgres) { p.janitorInterval = d }
+}
+
+// NewPostgresStorage creates the goqueue_jobs table if needed and returns a
+// Storage backed by db.
+func NewPostgresStorage(db *sql.DB, opts ...PostgresOption) (*Postgres, error) {
+	p := &Postgres{
+		db:              db,
+		leaseTimeout:    5 * time.Minute,
+		pollInterval:    5 * time.Second,
+		janitorInterval: 30 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	if _, err := db.Exec(schema); err != nil {
+		return nil, fmt.Errorf("postgres storage: create schema: %w", err)
+	}
+
+	// Recover jobs left "processing" by a worker that died before a prior
+	// process exited, so a crash doesn't strand them there forever.
+	if n, err := p.RecoverStuckJobs(context.Background()); err != nil {
+		log.Printf("[POSTGRES] startup recovery: %v", err)
+	} else if n > 0 {
+		log.Printf("[POSTGRES] startup recovery: reset %d stuck job(s) to pending", n)
+	}
+
+	janitorCtx, cancel := context.WithCancel(context.Background())
+	p.janitorCancel = cancel
+	p.janitorDone = make(chan struct{})
+	go p.runJanitor(janitorCtx)
+
+	return p, nil
+}
+
+// Close stops the background lease janitor started by NewPostgresStorage.
+// It does not close the underlying *sql.DB, which the caller still owns.
+func (p *Postgres) Close() error {
+	p.janitorCancel()
+	<-p.janitorDone
+	return nil
+}
+
+// runJanitor periodically reclaims jobs whose lease expired without the
+// owning worker releasing it (e.g. the worker process crashed mid-handler),
+// so a dead worker can't strand a job in "processing" forever.
+func (p *Postgres) runJanitor(ctx context.Context) {
+	defer close(p.janitorDone)
+
+	ticker := time.NewTicker(p.janitorInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if n, err := p.ReclaimExpiredLeases(ctx); err != nil {
+				log.Printf("[POSTGRES] janitor: %v", err)
+			} else if n > 0 {
+				log.Printf("[POSTGRES] janitor: reclaimed %d job(s) with an expired lease", n)
+			}
+		}
+	}
+}
+
+// ReclaimExpiredLeases resets jobs whose leased_until has passed back to
+// pending, incrementing attempts since the lease expired mid-run. It's what
+// keeps a crashed worker from permanently stranding a job it had acquired
+// via Acquire.
+func (p *Postgres) ReclaimExpiredLeases(ctx context.Context) (int64, error) {
+	res, err := p.db.ExecContext(ctx, `
+		UPDATE goqueue_jobs
+		SET status = 'pending', attempts = attempts + 1, leased_by = '', leased_until = NULL, updated_at = now()
+		WHERE status = 'processing' AND leased_until IS NOT NULL AND leased_until < now()
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("postgres storage: reclaim expired leases: %w", err)
+	}
+
+	return res.RowsAffected()
+}
+
+func (p *Postgres) Save(ctx context.Context, j *job.Job) error {
+	payload, err := json.Marshal(j.Payload)
+	if err != nil {
+		return fmt.Errorf("postgres storage: marshal payload: %w", err)
+	}
+
+	_, err = p.db.ExecContext(ctx, `
+		INSERT INTO goqueue_jobs
+			(id, type, payload, status, attempts, max_retry, priority, error, run_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`, j.ID, j.Type, payload, j.Status, j.Attempts, j.MaxRetry, j.Priority, j.Error, j.CreatedAt, j.CreatedAt, j.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("postgres storage: save job %s: %w", j.ID, err)
+	}
+
+	if _, err := p.db.ExecContext(ctx, `SELECT pg_notify($1, $2)`, notifyChannel, j.ID); err != nil {
+		return fmt.Errorf("postgres storage: notify for job %s: %w", j.ID, err)
+	}
+
+	return nil
+}
+
+func (p *Postgres) Get(ctx context.Context, id string) (*job.Job, error) {
+	row := p.db.QueryRowContext(ctx, `
+		SELECT id, type, payload, status, attempts, max_retry, priority, error, created_at, updated_at
+		FROM goqueue_jobs WHERE id = $1
+	`, id)
+
+	j, err := scanJob(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("postgres storage: get job %s: %w", id, err)
+	}
+
+	return j, nil
+}
+
+func (p *Postgres) List(ctx context.Context, status job.Status) ([]*job.Job, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT id, type, payload, status, attempts, max_retry, priority, error, created_at, updated_at
+		FROM goqueue_jobs WHERE status = $1
+		ORDER BY priority DESC, created_at ASC
+	`, status)
+	if err != nil {
+		return nil, fmt.Errorf("postgres storage: list jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []*job.Job
+	for rows.Next() {
+		j, err := scanJob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("postgres storage: scan job: %w", err)
+		}
+		jobs = append(jobs, j)
+	}
+
+	return jobs, rows.Err()
+}
+
+func (p *Postgres) Update(ctx context.Context, j *job.Job) error {
+	payload, err := json.Marshal(j.Payload)
+	if err != nil {
+		return fmt.Errorf("postgres storage: marshal payload: %w", err)
+	}
+
+	res, err := p.db.ExecContext(ctx, `
+		UPDATE goqueue_jobs
+		SET type = $2, payload = $3, status = $4, attempts = $5, max_retry = $6,
+		    priority = $7, error = $8, updated_at = $9
+		WHERE id = $1
+	`, j.ID, j.Type, payload, j.Status, j.Attempts, j.MaxRetry, j.Priority, j.Error, j.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("postgres storage: update job %s: %w", j.ID, err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("postgres storage: update job %s: %w", j.ID, err)
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (p *Postgres) Delete(ctx context.Context, id string) error {
+	res, err := p.db.ExecContext(ctx, `DELETE FROM goqueue_jobs WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("postgres storage: delete job %s: %w", id, err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("postgres storage: delete job %s: %w", id, err)
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// Next returns the highest-priority runnable job, if any. It does not claim
+// the row: the query runs outside a transaction, so its row lock ends with
+// the statement and concurrent callers can be handed the same job. Acquire
+// is the claiming path.
+func (p *Postgres) Next(ctx context.Context) (*job.Job, error) {
+	row := p.db.QueryRowContext(ctx, `
+		SELECT id, type, payload, status, attempts, max_retry, priority, error, created_at, updated_at
+		FROM goqueue_jobs
+		WHERE status = 'pending' AND (run_at IS NULL OR run_at <= now())
+		ORDER BY priority DESC, created_at ASC
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED
+	`)
+
+	j, err := scanJob(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("postgres storage: next job: %w", err)
+	}
+
+	return j, nil
+}
+
+// RecoverStuckJobs resets jobs that have been "processing" for longer than
+// the configured lease timeout back to pending, in case the worker that
+// owned them died without marking them failed or completed.
+func (p *Postgres) RecoverStuckJobs(ctx context.Context) (int64, error) {
+	res, err := p.db.ExecContext(ctx, `
+		UPDATE goqueue_jobs
+		SET status = 'pending', updated_at = now()
+		WHERE status = 'processing' AND updated_at < now() - $1::interval
+	`, p.leaseTimeout.String())
+	if err != nil {
+		return 0, fmt.Errorf("postgres storage: recover stuck jobs: %w", err)
+	}
+
+	return res.RowsAffected()
+}
+
+// Listen subscribes to goqueue_new_job notifications and invokes wake
+// whenever a new job is saved, falling back to a periodic poll in case a
+// notification is dropped (e.g. during a brief connection loss). Acquire
+// polls on its own and doesn't need Listen to function correctly; wake
+// has no path into Acquire, so running Listen does not make Acquire pick
+// up jobs faster than pollInterval. Run Listen in its own goroutine if the
+// caller has other local logic to nudge on new jobs.
+func (p *Postgres) Listen(ctx context.Context, dsn string, wake func()) error {
+	listener := pq.NewListener(dsn, 2*time.Second, time.Minute, func(ev pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Printf("[POSTGRES] listener error: %v", err)
+		}
+	})
+	defer listener.Close()
+
+	if err := listener.Listen(notifyChannel); err != nil {
+		return fmt.Errorf("postgres storage: listen on %s: %w", notifyChannel, err)
+	}
+
+	ticker := time.NewTicker(p.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-listener.Notify:
+			wake()
+		case <-ticker.C:
+			wake()
+		}
+	}
+}
+
+// AcquireLeader implements scheduler.LeaderElector using a session-level
+// pg_try_advisory_lock, so only one Queue process drives the Scheduler at a
+// time. The lock is held on a dedicated connection for as long as
+// leadership is held; call ReleaseLeader to give it up.
+func (p *Postgres) AcquireLeader(ctx context.Context) (bool, error) {
+	p.leaderMu.Lock()
+	defer p.leaderMu.Unlock()
+
+	if p.leaderConn == nil {
+		conn, err := p.db.Conn(ctx)
+		if err != nil {
+			return false, fmt.Errorf("postgres storage: acquire leader conn: %w", err)
+		}
+		p.leaderConn = conn
+	}
+
+	var acquired bool
+	if err := p.leaderConn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock($1)`, schedulerLeaderLockID).Scan(&acquired); err != nil {
+		return false, fmt.Errorf("postgres storage: try advisory lock: %w", err)
+	}
+
+	return acquired, nil
+}
+
+// ReleaseLeader gives up scheduler leadership acquired via AcquireLeader.
+func (p *Postgres) ReleaseLeader(ctx context.Context) error {
+	p.leaderMu.Lock()
+	defer p.leaderMu.Unlock()
+
+	if p.leaderConn == nil {
+		return nil
+	}
+
+	_, unlockErr := p.leaderConn.ExecContext(ctx, `SELECT pg_advisory_unlock($1)`, schedulerLeaderLockID)
+	closeErr := p.leaderConn.Close()
+	p.leaderConn = nil
+
+	if unlockErr != nil {
+		return fmt.Errorf("postgres storage: advisory unlock: %w", unlockErr)
+	}
+	return closeErr
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanJob(row rowScanner) (*job.Job, error) {
+	var (
+		j       job.Job
+		payload []byte
+		status  string
+	)
+
+	if err := row.Scan(
+		&j.ID, &j.Type, &payload, &status, &j.Attempts, &j.MaxRetry,
+		&j.Priority, &j.Error, &j.CreatedAt, &j.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	j.Status = job.Status(status)
+	if len(payload) > 0 {
+		if err := json.Unmarshal(payload, &j.Payload); err != nil {
+			return nil, fmt.Errorf("unmarshal payload: %w", err)
+		}
+	}
+
+	return &j, nil
+}
+
+// defaultLeaseTTL bounds how long a worker may hold a job before another
+// worker process is allowed to reclaim it.
+const defaultLeaseTTL = 30 * time.Second
+
+// Acquire implements job.Dispatcher: it blocks until it can claim the
+// highest-priority runnable row by leasing it to workerID, polling every
+// pollInterval in between attempts, so it can sit behind a worker pool's
+// blocking Acquire loop exactly like the in-memory dispatcher does. This is
+// what lets multiple Queue processes sharing this table actually cooperate
+// instead of each only ever seeing jobs submitted through itself.
+func (p *Postgres) Acquire(ctx context.Context, workerID string) (*job.Job, job.Lease, error) {
+	ticker := time.NewTicker(p.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		j, lease, err := p.tryAcquire(ctx, workerID)
+		if err == nil {
+			return j, lease, nil
+		}
+		if err != ErrNotFound {
+			return nil, nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// tryAcquire makes a single, non-blocking attempt to claim the
+// highest-priority runnable row, returning ErrNotFound if none is
+// available right now.
+func (p *Postgres) tryAcquire(ctx context.Context, workerID string) (*job.Job, job.Lease, error) {
+	row := p.db.QueryRowContext(ctx, `
+		UPDATE goqueue_jobs
+		SET status = 'processing', leased_by = $1, leased_until = now() + $2::interval
+		WHERE id = (
+			SELECT id FROM goqueue_jobs
+			WHERE status = 'pending' AND (run_at IS NULL OR run_at <= now())
+			ORDER BY priority DESC, created_at ASC
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, type, payload, status, attempts, max_retry, priority, error, created_at, updated_at
+	`, workerID, defaultLeaseTTL.String())
+
+	j, err := scanJob(row)
+	if err == sql.ErrNoRows {
+		return nil, nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("postgres storage: acquire job: %w", err)
+	}
+
+	// The row is already 'processing' in the table; hand the job back as
+	// pending, as the in-memory dispatcher does, so the worker's MarkRunning
+	// transition and its attempts increment apply.
+	j.Status = job.StatusPending
+
+	return j, &postgresLease{db: p.db, jobID: j.ID, workerID: workerID, expiresAt: time.Now().Add(defaultLeaseTTL)}, nil
+}
+
+// postgresLease renews or releases a job's leased_until row so other
+// processes know whether it's still being worked.
+type postgresLease struct {
+	db       *sql.DB
+	jobID    string
+	workerID string
+
+	mu        sync.Mutex
+	expiresAt time.Time
+}
+
+func (l *postgresLease) ExpiresAt() time.Time {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.expiresAt
+}
+
+func (l *postgresLease) Heartbeat(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	_, err := l.db.ExecContext(ctx, `
+		UPDATE goqueue_jobs SET leased_until = now() + $2::interval
+		WHERE id = $1 AND leased_by = $3
+	`, l.jobID, defaultLeaseTTL.String(), l.workerID)
+	if err != nil {
+		return fmt.Errorf("postgres storage: heartbeat lease for job %s: %w", l.jobID, err)
+	}
+
+	l.expiresAt = time.Now().Add(defaultLeaseTTL)
+	return nil
+}
+
+func (l *postgresLease) Release(ctx context.Context, status job.Status) error {
+	_, err := l.db.ExecContext(ctx, `
+		UPDATE goqueue_jobs SET leased_by = '', leased_until = NULL, status = $2, updated_at = now()
+		WHERE id = $1 AND leased_by = $3
+	`, l.jobID, status, l.workerID)
+	if err != nil {
+		return fmt.Errorf("postgres storage: release lease for job %s: %w", l.jobID, err)
+	}
+
+	return nil
+}
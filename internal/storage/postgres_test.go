@@ -0,0 +1,75 @@
+package storage
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// fakeRow is a rowScanner backed by a fixed column tuple, standing in for a
+// *sql.Row/*sql.Rows without a live Postgres connection. The rest of
+// Postgres's behavior (SELECT ... FOR UPDATE SKIP LOCKED, LISTEN/NOTIFY,
+// advisory locks) needs a real database and isn't exercised here.
+type fakeRow struct {
+	id, jobType, status, jobErr string
+	payload                     []byte
+	attempts, maxRetry, prio    int
+	createdAt, updatedAt        time.Time
+}
+
+func (r fakeRow) Scan(dest ...any) error {
+	*dest[0].(*string) = r.id
+	*dest[1].(*string) = r.jobType
+	*dest[2].(*[]byte) = r.payload
+	*dest[3].(*string) = r.status
+	*dest[4].(*int) = r.attempts
+	*dest[5].(*int) = r.maxRetry
+	*dest[6].(*int) = r.prio
+	*dest[7].(*string) = r.jobErr
+	*dest[8].(*time.Time) = r.createdAt
+	*dest[9].(*time.Time) = r.updatedAt
+	return nil
+}
+
+func TestScanJob(t *testing.T) {
+	t.Run("should populate every field and decode the payload", func(t *testing.T) {
+		payload, err := json.Marshal(map[string]any{"to": "a@b.com"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		now := time.Now().UTC().Truncate(time.Second)
+		row := fakeRow{
+			id: "job_1", jobType: "email", status: "pending", jobErr: "",
+			payload: payload, attempts: 1, maxRetry: 3, prio: 5,
+			createdAt: now, updatedAt: now,
+		}
+
+		j, err := scanJob(row)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if j.ID != "job_1" || j.Type != "email" || string(j.Status) != "pending" {
+			t.Errorf("unexpected job: %+v", j)
+		}
+		if j.Attempts != 1 || j.MaxRetry != 3 || j.Priority != 5 {
+			t.Errorf("unexpected job: %+v", j)
+		}
+		if j.Payload["to"] != "a@b.com" {
+			t.Errorf("expected payload to be decoded, got %v", j.Payload)
+		}
+	})
+
+	t.Run("should leave Payload nil for an empty payload column", func(t *testing.T) {
+		row := fakeRow{id: "job_2", status: "pending", payload: nil}
+
+		j, err := scanJob(row)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if j.Payload != nil {
+			t.Errorf("expected a nil payload, got %v", j.Payload)
+		}
+	})
+}
@@ -0,0 +1,34 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrScheduleNotFound is returned when a named schedule doesn't exist.
+var ErrScheduleNotFound = errors.New("schedule not found")
+
+// Schedule is a persisted definition of a recurring or one-shot job. Exactly
+// one of Interval, Cron or RunAt is set, depending on how it was registered.
+type Schedule struct {
+	Name      string
+	JobType   string
+	Payload   map[string]interface{}
+	MaxRetry  int
+	Interval  time.Duration
+	Cron      string
+	RunAt     time.Time
+	LastRunAt time.Time
+	NextRunAt time.Time
+}
+
+// ScheduleStorage persists schedule definitions and their run bookkeeping,
+// so schedules survive restarts and can be shared across Scheduler
+// instances.
+type ScheduleStorage interface {
+	SaveSchedule(ctx context.Context, s *Schedule) error
+	GetSchedule(ctx context.Context, name string) (*Schedule, error)
+	ListSchedules(ctx context.Context) ([]*Schedule, error)
+	UpdateSchedule(ctx context.Context, s *Schedule) error
+}
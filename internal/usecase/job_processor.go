@@ -2,31 +2,39 @@ package usecase
 
 import (
 	"context"
-	"fmt"
+	"errors"
 
-	"github.com/MohamedAljoke/goqueue/internal/entity"
+	"github.com/MohamedAljoke/goqueue/internal/handler"
+	"github.com/MohamedAljoke/goqueue/internal/job"
 )
 
-type JobProcessor struct {
-}
+// JobProcessor drives a job's status machine around a single handler
+// invocation. It's where the middleware chain installed on the handler
+// registry actually takes effect, since h is already wrapped by
+// HandlerRegistry.Get.
+type JobProcessor struct{}
 
 func NewJobProcessor() *JobProcessor {
 	return &JobProcessor{}
 }
 
-func (jp *JobProcessor) Process(tx context.Context, job *entity.Job, handler entity.HandlerFunc) error {
-	if err := job.MarkRunning(); err != nil {
-		return err
+// ProcessJob invokes h against j's payload and marks j completed or failed
+// based on the outcome. The caller is responsible for marking j running
+// beforehand, so it can persist that transition before the handler starts.
+func (jp *JobProcessor) ProcessJob(ctx context.Context, j *job.Job, h handler.HandlerFunc, fb job.Feedback) error {
+	err := h(ctx, j.Payload, fb)
+	if err == nil {
+		return j.MarkCompleted()
 	}
-	if err := handler(tx, job.Payload); err != nil {
-		if markErr := job.MarkFailed(err); markErr != nil {
-			return markErr
-		}
-		return fmt.Errorf("error handling process: %w", err)
+
+	if errors.Is(err, job.ErrUnchanged) {
+		j.Attempts--
+		return j.MarkCompleted()
 	}
-	if err := job.MarkCompleted(); err != nil {
-		return err
+
+	if markErr := j.MarkFailed(err); markErr != nil {
+		return markErr
 	}
 
-	return nil
+	return err
 }
@@ -0,0 +1,105 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/MohamedAljoke/goqueue/internal/job"
+)
+
+// memoryDispatcher preserves the pool's original in-process semantics: it
+// acquires straight from the priority queue. Unlike the queue itself, the
+// lease it hands out isn't a no-op: memoryDispatcher tracks every job it's
+// leased out but not yet had released, so ReclaimExpired can put one back
+// on the queue if the worker holding it dies without releasing it (see
+// runJanitor in worker.go).
+type memoryDispatcher struct {
+	queue    *priorityQueue
+	leaseTTL time.Duration
+
+	mu       sync.Mutex
+	inFlight map[string]*memoryLease
+}
+
+func newMemoryDispatcher(queue *priorityQueue, leaseTTL time.Duration) *memoryDispatcher {
+	return &memoryDispatcher{queue: queue, leaseTTL: leaseTTL, inFlight: make(map[string]*memoryLease)}
+}
+
+func (d *memoryDispatcher) Acquire(ctx context.Context, workerID string) (*job.Job, job.Lease, error) {
+	j, ok := d.queue.Next(ctx)
+	if !ok {
+		return nil, nil, ctx.Err()
+	}
+
+	lease := newMemoryLease(d, j, d.leaseTTL)
+	d.mu.Lock()
+	d.inFlight[j.ID] = lease
+	d.mu.Unlock()
+
+	return j, lease, nil
+}
+
+// ReclaimExpired re-enqueues every in-flight job whose lease has expired
+// without being released, incrementing attempts since the lease expired
+// mid-run. It mirrors Postgres.ReclaimExpiredLeases, giving the in-memory
+// dispatcher the same crash-recovery guarantee.
+func (d *memoryDispatcher) ReclaimExpired(now time.Time) int {
+	d.mu.Lock()
+	var expired []*job.Job
+	for id, lease := range d.inFlight {
+		if now.Before(lease.ExpiresAt()) {
+			continue
+		}
+		expired = append(expired, lease.job)
+		delete(d.inFlight, id)
+	}
+	d.mu.Unlock()
+
+	for _, j := range expired {
+		j.Status = job.StatusPending
+		j.Attempts++
+		j.UpdatedAt = now
+		d.queue.Push(j)
+	}
+
+	return len(expired)
+}
+
+func (d *memoryDispatcher) release(jobID string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.inFlight, jobID)
+}
+
+type memoryLease struct {
+	mu         sync.Mutex
+	dispatcher *memoryDispatcher
+	job        *job.Job
+	ttl        time.Duration
+	expiresAt  time.Time
+}
+
+func newMemoryLease(d *memoryDispatcher, j *job.Job, ttl time.Duration) *memoryLease {
+	return &memoryLease{dispatcher: d, job: j, ttl: ttl, expiresAt: time.Now().Add(ttl)}
+}
+
+func (l *memoryLease) ExpiresAt() time.Time {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.expiresAt
+}
+
+func (l *memoryLease) Heartbeat(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.expiresAt = time.Now().Add(l.ttl)
+	return nil
+}
+
+func (l *memoryLease) Release(ctx context.Context, status job.Status) error {
+	l.dispatcher.release(l.job.ID)
+	return nil
+}
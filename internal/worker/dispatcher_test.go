@@ -0,0 +1,93 @@
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryDispatcher_Acquire(t *testing.T) {
+	t.Run("should hand out the pushed job", func(t *testing.T) {
+		queue := newPriorityQueue()
+		d := newMemoryDispatcher(queue, time.Minute)
+
+		want := newTestJob(0)
+		queue.Push(want)
+
+		got, lease, err := d.Acquire(context.Background(), "worker_0")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != want {
+			t.Fatalf("expected the pushed job back")
+		}
+		if lease == nil {
+			t.Fatal("expected a non-nil lease")
+		}
+	})
+}
+
+func TestMemoryDispatcher_ReclaimExpired(t *testing.T) {
+	t.Run("should re-enqueue a job whose lease expired without being released", func(t *testing.T) {
+		queue := newPriorityQueue()
+		d := newMemoryDispatcher(queue, time.Millisecond)
+
+		j := newTestJob(0)
+		queue.Push(j)
+
+		_, _, err := d.Acquire(context.Background(), "worker_0")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		attemptsBefore := j.Attempts
+		n := d.ReclaimExpired(time.Now().Add(time.Hour))
+		if n != 1 {
+			t.Fatalf("expected 1 reclaimed job, got %d", n)
+		}
+		if j.Attempts != attemptsBefore+1 {
+			t.Errorf("expected attempts to be incremented on reclaim, got %d", j.Attempts)
+		}
+
+		got, ok := queue.Next(context.Background())
+		if !ok || got != j {
+			t.Fatal("expected the reclaimed job back on the queue")
+		}
+	})
+
+	t.Run("should leave a released job alone", func(t *testing.T) {
+		queue := newPriorityQueue()
+		d := newMemoryDispatcher(queue, time.Millisecond)
+
+		j := newTestJob(0)
+		queue.Push(j)
+
+		_, lease, err := d.Acquire(context.Background(), "worker_0")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := lease.Release(context.Background(), j.Status); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if n := d.ReclaimExpired(time.Now().Add(time.Hour)); n != 0 {
+			t.Errorf("expected 0 reclaimed jobs after release, got %d", n)
+		}
+	})
+
+	t.Run("should leave an unexpired lease alone", func(t *testing.T) {
+		queue := newPriorityQueue()
+		d := newMemoryDispatcher(queue, time.Hour)
+
+		j := newTestJob(0)
+		queue.Push(j)
+
+		if _, _, err := d.Acquire(context.Background(), "worker_0"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if n := d.ReclaimExpired(time.Now()); n != 0 {
+			t.Errorf("expected 0 reclaimed jobs before the lease expires, got %d", n)
+		}
+	})
+}
@@ -0,0 +1,104 @@
+package worker
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+
+	"github.com/MohamedAljoke/goqueue/internal/job"
+)
+
+// jobHeap orders jobs by priority (descending) and, within the same
+// priority, by CreatedAt (ascending) so older jobs aren't starved.
+type jobHeap []*job.Job
+
+func (h jobHeap) Len() int { return len(h) }
+
+func (h jobHeap) Less(i, j int) bool {
+	if h[i].Priority != h[j].Priority {
+		return h[i].Priority > h[j].Priority
+	}
+	return h[i].CreatedAt.Before(h[j].CreatedAt)
+}
+
+func (h jobHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *jobHeap) Push(x any) { *h = append(*h, x.(*job.Job)) }
+
+func (h *jobHeap) Pop() any {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return item
+}
+
+// priorityQueue is a blocking, priority-ordered queue of jobs, guarded by a
+// mutex and condition variable so workers can block until work arrives or
+// the pool is shutting down.
+type priorityQueue struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	heap   jobHeap
+	closed bool
+}
+
+func newPriorityQueue() *priorityQueue {
+	pq := &priorityQueue{}
+	pq.cond = sync.NewCond(&pq.mu)
+	return pq
+}
+
+// Push inserts a job, keeping its priority, and wakes one waiting worker.
+func (pq *priorityQueue) Push(j *job.Job) {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+
+	if pq.closed {
+		return
+	}
+	heap.Push(&pq.heap, j)
+	pq.cond.Signal()
+}
+
+// Next blocks until the highest-priority job is available, the queue is
+// closed, or ctx is cancelled.
+func (pq *priorityQueue) Next(ctx context.Context) (*job.Job, bool) {
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			pq.mu.Lock()
+			pq.cond.Broadcast()
+			pq.mu.Unlock()
+		case <-done:
+		}
+	}()
+
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+
+	for pq.heap.Len() == 0 && !pq.closed {
+		if ctx.Err() != nil {
+			return nil, false
+		}
+		pq.cond.Wait()
+	}
+
+	if pq.heap.Len() == 0 {
+		return nil, false
+	}
+
+	return heap.Pop(&pq.heap).(*job.Job), true
+}
+
+// Close marks the queue closed and wakes every waiting worker.
+func (pq *priorityQueue) Close() {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+
+	pq.closed = true
+	pq.cond.Broadcast()
+}
@@ -0,0 +1,95 @@
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/MohamedAljoke/goqueue/internal/job"
+)
+
+func newTestJob(priority int) *job.Job {
+	j := job.NewJob(3)
+	j.Priority = priority
+	return j
+}
+
+func TestPriorityQueue_Next(t *testing.T) {
+	t.Run("should return jobs in priority order", func(t *testing.T) {
+		pq := newPriorityQueue()
+		low, mid, high := newTestJob(0), newTestJob(5), newTestJob(10)
+
+		pq.Push(low)
+		pq.Push(high)
+		pq.Push(mid)
+
+		ctx := context.Background()
+		for _, want := range []*job.Job{high, mid, low} {
+			got, ok := pq.Next(ctx)
+			if !ok {
+				t.Fatalf("expected a job, got none")
+			}
+			if got != want {
+				t.Fatalf("expected job with priority %d, got priority %d", want.Priority, got.Priority)
+			}
+		}
+	})
+
+	t.Run("should preserve submission order within the same priority", func(t *testing.T) {
+		pq := newPriorityQueue()
+		first := newTestJob(1)
+		pq.Push(first)
+		time.Sleep(time.Millisecond)
+		second := newTestJob(1)
+		pq.Push(second)
+
+		ctx := context.Background()
+		got, _ := pq.Next(ctx)
+		if got != first {
+			t.Fatalf("expected the older same-priority job first")
+		}
+		got, _ = pq.Next(ctx)
+		if got != second {
+			t.Fatalf("expected the newer same-priority job second")
+		}
+	})
+
+	t.Run("should unblock and return false when the context is cancelled", func(t *testing.T) {
+		pq := newPriorityQueue()
+		ctx, cancel := context.WithCancel(context.Background())
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			if _, ok := pq.Next(ctx); ok {
+				t.Error("expected no job after cancellation")
+			}
+		}()
+
+		cancel()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("Next did not return after context cancellation")
+		}
+	})
+
+	t.Run("should unblock and return false when closed", func(t *testing.T) {
+		pq := newPriorityQueue()
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			if _, ok := pq.Next(context.Background()); ok {
+				t.Error("expected no job after Close")
+			}
+		}()
+
+		pq.Close()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("Next did not return after Close")
+		}
+	})
+}
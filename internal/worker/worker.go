@@ -2,16 +2,37 @@ package worker
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"time"
 
 	"github.com/MohamedAljoke/goqueue/internal/handler"
 	"github.com/MohamedAljoke/goqueue/internal/job"
+	"github.com/MohamedAljoke/goqueue/internal/storage"
+	"github.com/MohamedAljoke/goqueue/internal/usecase"
 )
 
+// defaultLeaseTTL bounds how long a worker may hold a job before another
+// worker is allowed to reclaim it, should the original die mid-handler.
+const defaultLeaseTTL = 30 * time.Second
+
+// defaultJanitorInterval mirrors Postgres's WithJanitorInterval default,
+// governing how often the in-memory janitor checks for expired leases.
+const defaultJanitorInterval = 30 * time.Second
+
 type WorkerPool struct {
-	jobChan  chan *job.Job
-	handlers *handler.HandlerRegistry
+	jobs       *priorityQueue
+	dispatcher job.Dispatcher
+	// external is true when dispatcher claims jobs directly from shared
+	// storage (e.g. Postgres) rather than from this pool's in-process jobs
+	// heap, so Submit knows pushing onto jobs would just leak memory, and
+	// Start knows the storage's own janitor (not runJanitor) owns recovery.
+	external  bool
+	memJobs   *memoryDispatcher
+	handlers  *handler.HandlerRegistry
+	processor *usecase.JobProcessor
+	storage   storage.Storage
+	backoff   job.BackoffStrategy
 
 	workerCount int
 	ctx         context.Context
@@ -20,15 +41,35 @@ type WorkerPool struct {
 	wg sync.WaitGroup
 }
 
-func NewWorkerPool(workerCount int, handlers *handler.HandlerRegistry) *WorkerPool {
+func NewWorkerPool(workerCount int, handlers *handler.HandlerRegistry, store storage.Storage, backoff job.BackoffStrategy) *WorkerPool {
 	ctx, cancel := context.WithCancel(context.Background())
-	poolChan := make(chan *job.Job, workerCount*2)
+	jobs := newPriorityQueue()
+
+	if backoff == nil {
+		backoff = job.DefaultBackoff{}
+	}
+
+	// A storage backend that also implements job.Dispatcher (e.g.
+	// storage.Postgres) can hand out leased jobs straight from the shared
+	// table, so every Queue process pointed at it cooperates instead of
+	// each only ever seeing jobs submitted through itself.
+	memJobs := newMemoryDispatcher(jobs, defaultLeaseTTL)
+	dispatcher, external := job.Dispatcher(memJobs), false
+	if d, ok := store.(job.Dispatcher); ok {
+		dispatcher, external = d, true
+	}
 
 	pool := &WorkerPool{
-		jobChan:     poolChan,
+		jobs:        jobs,
+		dispatcher:  dispatcher,
+		external:    external,
+		memJobs:     memJobs,
 		ctx:         ctx,
 		cancel:      cancel,
 		handlers:    handlers,
+		processor:   usecase.NewJobProcessor(),
+		storage:     store,
+		backoff:     backoff,
 		workerCount: workerCount,
 	}
 
@@ -38,62 +79,139 @@ func NewWorkerPool(workerCount int, handlers *handler.HandlerRegistry) *WorkerPo
 func (wp *WorkerPool) Start() {
 	for i := 0; i < wp.workerCount; i++ {
 		wp.wg.Add(1)
-		go wp.worker()
+		go wp.worker(fmt.Sprintf("worker_%d", i))
 	}
 
+	// The in-memory dispatcher is only actually in play when we're not
+	// deferring to an external one (e.g. Postgres, which runs its own
+	// janitor tied to its own lifetime); starting this one unconditionally
+	// would just reclaim leases that storage.Postgres itself already owns.
+	if !wp.external {
+		wp.wg.Add(1)
+		go wp.runJanitor()
+	}
 }
 
-func (wp *WorkerPool) Submit(job *job.Job) {
-	wp.jobChan <- job
+// runJanitor periodically reclaims jobs whose lease expired without the
+// owning worker releasing or renewing it, so a job can't be stranded behind
+// a lease that will never be renewed. It runs inside this process, so it
+// does not recover jobs lost when the process itself exits. It mirrors
+// storage.Postgres's own runJanitor.
+func (wp *WorkerPool) runJanitor() {
+	defer wp.wg.Done()
+
+	ticker := time.NewTicker(defaultJanitorInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-wp.ctx.Done():
+			return
+		case <-ticker.C:
+			wp.memJobs.ReclaimExpired(time.Now())
+		}
+	}
+}
+
+// Submit enqueues a job, keeping it ordered ahead of lower-priority,
+// newer jobs. Re-enqueueing a job after a retry backoff goes through the
+// same path, so it keeps its priority instead of starving behind fresh
+// low-priority work.
+//
+// When the pool is backed by an external dispatcher (storage that claims
+// jobs directly, e.g. Postgres), Submit is a no-op: the storage Save/Update
+// call that preceded it already made the job visible to every process
+// sharing that storage, and nothing ever drains this pool's jobs heap in
+// that mode.
+func (wp *WorkerPool) Submit(j *job.Job) {
+	if wp.external {
+		return
+	}
+	wp.jobs.Push(j)
 }
 
 func (wp *WorkerPool) Stop() {
 	wp.cancel()
-	close(wp.jobChan)
+	wp.jobs.Close()
 	wp.wg.Wait()
 }
-func (wp *WorkerPool) worker() {
+func (wp *WorkerPool) worker(workerID string) {
 	defer wp.wg.Done()
 
 	for {
-		select {
-		case j, ok := <-wp.jobChan:
-			if !ok {
-				return
-			}
-			wp.processJob(j)
-
-		case <-wp.ctx.Done():
+		j, lease, err := wp.dispatcher.Acquire(wp.ctx, workerID)
+		if err != nil {
 			return
 		}
+		wp.processJob(j, lease)
 	}
 }
 
-func (wp *WorkerPool) processJob(j *job.Job) {
+func (wp *WorkerPool) processJob(j *job.Job, lease job.Lease) {
 	handler, err := wp.handlers.Get(j.Type)
 	if err != nil {
 		_ = j.MarkFailed(err)
+		wp.flush(j)
+		_ = lease.Release(wp.ctx, j.Status)
 
 		return
 	}
 
 	if err := j.MarkRunning(); err != nil {
 		_ = j.MarkFailed(err)
+		wp.flush(j)
+		_ = lease.Release(wp.ctx, j.Status)
 
 		return
 	}
+	wp.flush(j)
 
-	err = handler(wp.ctx, j.Payload)
-	if err == nil {
-		_ = j.MarkCompleted()
-		return
-	}
+	// Keep renewing the lease while the handler runs, so a slow-but-alive
+	// handler doesn't lose its claim to another worker.
+	heartbeatCtx, stopHeartbeat := context.WithCancel(wp.ctx)
+	defer stopHeartbeat()
+	go wp.heartbeat(heartbeatCtx, lease)
+
+	fb := job.NewFeedback(j)
+	err = wp.processor.ProcessJob(wp.ctx, j, handler, fb)
+	stopHeartbeat()
 
-	_ = j.MarkFailed(err)
+	wp.flush(j)
+	_ = lease.Release(wp.ctx, j.Status)
 
-	if j.Status == job.StatusPending {
-		time.AfterFunc(j.BackoffDuration(), func() {
+	if err != nil && j.Status == job.StatusPending {
+		time.AfterFunc(wp.backoff.NextDelay(j.Attempts), func() {
 			wp.Submit(j)
 		})
 	}
 }
+
+func (wp *WorkerPool) heartbeat(ctx context.Context, lease job.Lease) {
+	interval := time.Until(lease.ExpiresAt()) / 3
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := lease.Heartbeat(ctx); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// flush persists the job's current state, including its buffered
+// feedback logs and progress, if the pool has storage configured.
+func (wp *WorkerPool) flush(j *job.Job) {
+	if wp.storage == nil {
+		return
+	}
+	_ = wp.storage.Update(wp.ctx, j)
+}